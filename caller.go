@@ -0,0 +1,169 @@
+package logger
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/willjp/go-logger/internal/spinlock"
+)
+
+const maxHelperDepth = 32
+
+var (
+	helpers     = map[string]struct{}{}
+	helpersLock = &spinlock.SpinLock{}
+)
+
+// Helper marks the calling function as a logging helper (like testing.T.Helper).
+// When determining the caller of a message, helper functions are skipped,
+// so wrappers around a logger report the line that called the wrapper.
+//
+//	Ex.
+//	    func logRequest(l logger.Interface, r *http.Request) {
+//	        logger.Helper()
+//	        l.Info("request", logger.F("path", r.URL.Path))
+//	    }
+func Helper() {
+	var pcs [1]uintptr
+	runtime.Callers(2, pcs[:])
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+	helpersLock.Acquire()
+	defer helpersLock.Release()
+	helpers[frame.Function] = struct{}{}
+}
+
+func isHelper(function string) bool {
+	helpersLock.Acquire()
+	defer helpersLock.Release()
+	_, ok := helpers[function]
+	return ok
+}
+
+func hasHelpers() bool {
+	helpersLock.Acquire()
+	defer helpersLock.Release()
+	return len(helpers) > 0
+}
+
+// Returns the frame calldepth frames above the caller of callerFrame (like runtime.Caller),
+// skipping functions marked by Helper.
+func callerFrame(calldepth int) (runtime.Frame, bool) {
+	var pcs [maxHelperDepth]uintptr
+	depth := 1
+	if hasHelpers() {
+		depth = maxHelperDepth
+	}
+	n := runtime.Callers(calldepth+2, pcs[:depth])
+	if n == 0 {
+		return runtime.Frame{}, false
+	}
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !more || !isHelper(frame.Function) {
+			return frame, true
+		}
+	}
+}
+
+// CallerMode determines how the caller of a message is recorded,
+// when flags include log.Llongfile or log.Lshortfile.
+//
+//	Ex.
+//	    CallerModeFlags                   /home/user/src/project/internal/db/conn.go:23
+//	    CallerModeRelative                internal/db/conn.go:23
+//	    CallerModePackage                 db/conn.go:23
+//	    CallerModePackage|CallerModeFunc  db.(*Conn).Query db/conn.go:23
+type CallerMode uint8
+
+const (
+	CallerModeFlags    CallerMode = 0      // path as reported by the runtime (default)
+	CallerModeRelative CallerMode = 1      // path relative to the main module's root (from build info), other modules are prefixed by their import path
+	CallerModePackage  CallerMode = 2      // package name and file name
+	CallerModeFunc     CallerMode = 1 << 4 // also record the calling function, combined with one of the above
+)
+
+// Import paths of the main module (ex. "github.com/user/project")
+// and of the main package (ex. "github.com/user/project/cmd/server"), empty when unavailable.
+var mainModule, mainPackage = func() (string, string) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", ""
+	}
+	if info.Path == "command-line-arguments" { // go run main.go
+		return info.Main.Path, ""
+	}
+	return info.Main.Path, info.Path
+}()
+
+// Sets the file, line and function of r from the caller's frame.
+func (m CallerMode) setCaller(r *Record, frame runtime.Frame) {
+	r.File, r.Line = frame.File, frame.Line
+	if m&CallerModeFunc != 0 {
+		r.Function = unescapeFunction(frame.Function)
+	}
+	if frame.Function == "" {
+		return
+	}
+
+	pkg := unescapeFunction(funcPackage(frame.Function))
+	file := frame.File[strings.LastIndexByte(frame.File, '/')+1:]
+	switch m &^ CallerModeFunc {
+	case CallerModeRelative:
+		if pkg == "main" {
+			pkg = mainPackage // functions in package main are named main.X, regardless of their directory
+		}
+		switch {
+		case pkg == "":
+			r.File = file
+		case mainModule == "":
+			r.File = pkg + "/" + file
+		case pkg == mainModule:
+			r.File = file
+		case strings.HasPrefix(pkg, mainModule+"/"):
+			r.File = pkg[len(mainModule)+1:] + "/" + file
+		default:
+			r.File = pkg + "/" + file
+		}
+	case CallerModePackage:
+		r.File = pkg[strings.LastIndexByte(pkg, '/')+1:] + "/" + file
+	}
+}
+
+// Import path of the package a function belongs to
+// (ex. "github.com/user/project/db" for "github.com/user/project/db.(*Conn).Query").
+// The result is escaped like the function name (see unescapeFunction).
+func funcPackage(function string) string {
+	if bracket := strings.IndexByte(function, '['); bracket >= 0 {
+		function = function[:bracket] // type parameters of generic functions
+	}
+	slash := strings.LastIndexByte(function, '/')
+	dot := strings.IndexByte(function[slash+1:], '.')
+	if dot < 0 {
+		return function
+	}
+	return function[:slash+1+dot]
+}
+
+// Returns a function name as reported by the runtime, with it's import path unescaped.
+// The runtime escapes '.' in the last element of the import path, along with '%', '"' and control characters
+// (ex. "example.com/svc%2ev2.Do" for Do in "example.com/svc.v2").
+func unescapeFunction(function string) string {
+	if !strings.Contains(function, "%") {
+		return function
+	}
+	builder := strings.Builder{}
+	for i := 0; i < len(function); i++ {
+		if function[i] == '%' && i+2 < len(function) {
+			if b, err := strconv.ParseUint(function[i+1:i+3], 16, 8); err == nil {
+				builder.WriteByte(byte(b))
+				i += 2
+				continue
+			}
+		}
+		builder.WriteByte(function[i])
+	}
+	return builder.String()
+}
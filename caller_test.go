@@ -0,0 +1,236 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"regexp"
+	"runtime"
+	"testing"
+)
+
+// wrapper around a logger, as a team's logging library would have
+func warnWrapper(l Interface, msg string) {
+	l.Warn(msg)
+}
+
+// wrapper marked as a helper
+func warnHelper(l Interface, msg string) {
+	Helper()
+	l.Warn(msg)
+}
+
+// helper calling another helper
+func nestedWarnHelper(l Interface, msg string) {
+	Helper()
+	warnHelper(l, msg)
+}
+
+// Returns 'file:line' of the line after the call to nextLine
+func nextLine() string {
+	_, file, line, _ := runtime.Caller(1)
+	return fmt.Sprintf("%s:%d", file, line+1)
+}
+
+func TestCallerSkip(t *testing.T) {
+	t.Run("Logger", func(t *testing.T) {
+		var buf bytes.Buffer
+		lg := New(&buf, WithCallerSkip(1))
+		lg.SetFlags(log.Llongfile)
+		expect := nextLine()
+		warnWrapper(&lg, "disk almost full")
+		if received := buf.String(); received != "[WARN ] "+expect+": disk almost full\n" {
+			t.Errorf("Expected caller %s, Received %q", expect, received)
+		}
+	})
+
+	t.Run("StubLogger", func(t *testing.T) {
+		stub := NewStubLogger()
+		stub.SetCallerSkip(1)
+		expect := nextLine()
+		warnWrapper(&stub, "disk almost full")
+		r := stub.Records()[0]
+		if received := fmt.Sprintf("%s:%d", r.File, r.Line); received != expect {
+			t.Errorf("Expected caller %s, Received %s", expect, received)
+		}
+	})
+}
+
+func TestHelper(t *testing.T) {
+	tcases := []struct {
+		test string
+		log  func(l Interface, msg string)
+	}{
+		{test: "Helper", log: warnHelper},
+		{test: "Nested helpers", log: nestedWarnHelper},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			var buf bytes.Buffer
+			lg := New(&buf)
+			lg.SetFlags(log.Llongfile)
+			expect := nextLine()
+			tcase.log(&lg, "disk almost full")
+			if received := buf.String(); received != "[WARN ] "+expect+": disk almost full\n" {
+				t.Errorf("Expected caller %s, Received %q", expect, received)
+			}
+
+			stub := NewStubLogger()
+			expect = nextLine()
+			tcase.log(&stub, "disk almost full")
+			r := stub.Records()[0]
+			if received := fmt.Sprintf("%s:%d", r.File, r.Line); received != expect {
+				t.Errorf("Expected caller %s, Received %s", expect, received)
+			}
+		})
+	}
+}
+
+func TestCallerModeSetCaller(t *testing.T) {
+	defer func(module, pkg string) { mainModule, mainPackage = module, pkg }(mainModule, mainPackage)
+	mainModule, mainPackage = "github.com/user/project", "github.com/user/project/cmd/server"
+
+	tcases := []struct {
+		test     string
+		mode     CallerMode
+		function string
+		file     string
+		expect   string
+		expectFn string
+	}{
+		{
+			test:     "Flags",
+			mode:     CallerModeFlags,
+			function: "github.com/user/project/internal/db.(*Conn).Query",
+			file:     "/home/user/src/project/internal/db/conn.go",
+			expect:   "/home/user/src/project/internal/db/conn.go",
+		},
+		{
+			test:     "Relative",
+			mode:     CallerModeRelative,
+			function: "github.com/user/project/internal/db.(*Conn).Query",
+			file:     "/home/user/src/project/internal/db/conn.go",
+			expect:   "internal/db/conn.go",
+		},
+		{
+			test:     "Relative in module root",
+			mode:     CallerModeRelative,
+			function: "github.com/user/project.Run",
+			file:     "/home/user/src/project/run.go",
+			expect:   "run.go",
+		},
+		{
+			test:     "Relative in other module",
+			mode:     CallerModeRelative,
+			function: "github.com/other/lib.Do",
+			file:     "/home/user/go/pkg/mod/github.com/other/lib@v1.0.0/do.go",
+			expect:   "github.com/other/lib/do.go",
+		},
+		{
+			test:     "Relative in package main",
+			mode:     CallerModeRelative,
+			function: "main.main",
+			file:     "/home/user/src/project/cmd/server/main.go",
+			expect:   "cmd/server/main.go",
+		},
+		{
+			test:     "Package",
+			mode:     CallerModePackage,
+			function: "github.com/user/project/internal/db.(*Conn).Query",
+			file:     "/home/user/src/project/internal/db/conn.go",
+			expect:   "db/conn.go",
+		},
+		{
+			test:     "Package of generic function",
+			mode:     CallerModePackage,
+			function: "github.com/user/project/internal/db.Get[...]",
+			file:     "/home/user/src/project/internal/db/get.go",
+			expect:   "db/get.go",
+		},
+		{
+			test:     "Function",
+			mode:     CallerModePackage | CallerModeFunc,
+			function: "github.com/user/project/internal/db.(*Conn).Query",
+			file:     "/home/user/src/project/internal/db/conn.go",
+			expect:   "db/conn.go",
+			expectFn: "github.com/user/project/internal/db.(*Conn).Query",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			r := Record{}
+			tcase.mode.setCaller(&r, runtime.Frame{Function: tcase.function, File: tcase.file, Line: 23})
+			if r.File != tcase.expect || r.Line != 23 || r.Function != tcase.expectFn {
+				t.Errorf("Expected %s:23 %q, Received %s:%d %q", tcase.expect, tcase.expectFn, r.File, r.Line, r.Function)
+			}
+		})
+	}
+}
+
+func TestLoggerSetCallerMode(t *testing.T) {
+	var buf bytes.Buffer
+	lg := New(&buf, WithCallerMode(CallerModeRelative|CallerModeFunc))
+	lg.SetFlags(log.Llongfile)
+	lg.Warn("disk almost full")
+	expect := regexp.MustCompile(`^\[WARN \] github.com/willjp/go-logger\.TestLoggerSetCallerMode caller_test.go:[0-9]+: disk almost full\n$`)
+	if !expect.MatchString(buf.String()) {
+		t.Errorf("Expected match for %s, Received %q", expect, buf.String())
+	}
+}
+
+func TestCallerModeSetCallerMainUnknown(t *testing.T) {
+	defer func(module, pkg string) { mainModule, mainPackage = module, pkg }(mainModule, mainPackage)
+	mainModule, mainPackage = "", "" // ex. built without module support
+
+	r := Record{}
+	CallerModeRelative.setCaller(&r, runtime.Frame{Function: "main.main", File: "/home/user/src/project/main.go", Line: 23})
+	if r.File != "main.go" {
+		t.Errorf("Expected main.go, Received %s", r.File)
+	}
+}
+
+func TestCallerModeSetCallerEscapedPath(t *testing.T) {
+	defer func(module, pkg string) { mainModule, mainPackage = module, pkg }(mainModule, mainPackage)
+	mainModule, mainPackage = "example.com/svc.v2", "example.com/svc.v2"
+
+	tcases := []struct {
+		test     string
+		mode     CallerMode
+		function string
+		file     string
+		expect   string
+		expectFn string
+	}{
+		{
+			test:     "Relative in module root",
+			mode:     CallerModeRelative,
+			function: "example.com/svc%2ev2.Run",
+			file:     "/src/svc/run.go",
+			expect:   "run.go",
+		},
+		{
+			test:     "Relative in dotted package",
+			mode:     CallerModeRelative,
+			function: "example.com/svc.v2/util%2ev2.Do",
+			file:     "/src/svc/util.v2/u.go",
+			expect:   "util.v2/u.go",
+		},
+		{
+			test:     "Package and function",
+			mode:     CallerModePackage | CallerModeFunc,
+			function: "example.com/svc.v2/util%2ev2.Do",
+			file:     "/src/svc/util.v2/u.go",
+			expect:   "util.v2/u.go",
+			expectFn: "example.com/svc.v2/util.v2.Do",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			r := Record{}
+			tcase.mode.setCaller(&r, runtime.Frame{Function: tcase.function, File: tcase.file, Line: 5})
+			if r.File != tcase.expect || r.Function != tcase.expectFn {
+				t.Errorf("Expected %s %q, Received %s %q", tcase.expect, tcase.expectFn, r.File, r.Function)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package logger
+
+import "time"
+
+// Clock provides the time that records are logged at.
+// A fake Clock makes timestamps in formatted lines deterministic in tests (see logtest.FakeClock).
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the default Clock, reading the system time.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/willjp/go-logger/internal/term"
+)
+
+// ColorMode determines when ConsoleFormatter writes ANSI colours.
+type ColorMode int8
+
+const (
+	ColorModeAuto   ColorMode = iota // colour when writing to a terminal, unless NO_COLOR is set
+	ColorModeAlways                  // always colour
+	ColorModeNever                   // never colour
+)
+
+const (
+	colorDim   Color = "2"
+	colorReset       = "\x1b[0m"
+)
+
+// ConsoleFormatter renders human-friendly lines for local development,
+// with coloured level tags, dimmed timestamps and highlighted fields.
+//
+//	Ex.
+//	    2009/01/23 01:23:23 WARN  main.go:23: disk almost full free=2%
+type ConsoleFormatter struct {
+	Color bool // write ANSI colours
+}
+
+// Creates a ConsoleFormatter for output written to w.
+func NewConsoleFormatter(w io.Writer, mode ColorMode) *ConsoleFormatter {
+	return &ConsoleFormatter{Color: useColor(w, mode)}
+}
+
+func (f *ConsoleFormatter) Format(r *Record, flags int) []byte {
+	var buf []byte
+	if timestamp := appendTimestamp(nil, r, flags); len(timestamp) > 0 {
+		buf = f.appendColor(buf, colorDim, string(timestamp))
+		buf = append(buf, ' ')
+	}
+	buf = f.appendColor(buf, r.Level.Color(), fmt.Sprintf("%-5s", r.Level))
+	buf = append(buf, ' ')
+	if caller := appendFuncCaller(nil, r, flags); len(caller) > 0 {
+		buf = f.appendColor(buf, colorDim, string(caller)+":")
+		buf = append(buf, ' ')
+	}
+	buf = append(buf, r.Message...)
+	for _, field := range textFields(r.Fields) {
+		buf = append(buf, ' ')
+		buf = f.appendColor(buf, ColorCyan, field.Key+"=")
+		buf = append(buf, field.Text...)
+	}
+	for _, frame := range r.Stack {
+		buf = append(buf, "\n\t"...)
+		buf = f.appendColor(buf, colorDim, frame.String())
+	}
+	if len(buf) == 0 || buf[len(buf)-1] != '\n' {
+		buf = append(buf, '\n')
+	}
+	return buf
+}
+
+// Appends text, wrapped in ANSI escape codes for color if enabled.
+func (f *ConsoleFormatter) appendColor(buf []byte, color Color, text string) []byte {
+	if !f.Color || color == ColorNone {
+		return append(buf, text...)
+	}
+	buf = append(buf, "\x1b["...)
+	buf = append(buf, color...)
+	buf = append(buf, 'm')
+	buf = append(buf, text...)
+	return append(buf, colorReset...)
+}
+
+// Determines if colours should be written to w.
+func useColor(w io.Writer, mode ColorMode) bool {
+	switch mode {
+	case ColorModeAlways:
+		return true
+	case ColorModeNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return isTerminal(w)
+}
+
+// Reports whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(interface{ Fd() uintptr })
+	return ok && term.IsTerminal(file.Fd())
+}
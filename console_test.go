@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConsoleFormatter(t *testing.T) {
+	r := Record{
+		Time:    time.Date(2009, 1, 23, 1, 23, 23, 0, time.Local),
+		Level:   LvWarn,
+		Message: "disk almost full",
+		File:    "/src/main.go",
+		Line:    23,
+		Fields:  []Field{F("free", "2%")},
+	}
+
+	t.Run("Without colour", func(t *testing.T) {
+		f := ConsoleFormatter{}
+		expects := "2009/01/23 01:23:23 WARN  main.go:23: disk almost full free=2%\n"
+		received := string(f.Format(&r, log.LstdFlags|log.Lshortfile))
+		if received != expects {
+			t.Errorf("Expected '%s', Received '%s'", expects, received)
+		}
+	})
+
+	t.Run("With colour", func(t *testing.T) {
+		f := ConsoleFormatter{Color: true}
+		expects := "\x1b[2m01:23:23\x1b[0m \x1b[33mWARN \x1b[0m disk almost full \x1b[36mfree=\x1b[0m2%\n"
+		received := string(f.Format(&r, log.Ltime))
+		if received != expects {
+			t.Errorf("Expected '%q', Received '%q'", expects, received)
+		}
+	})
+}
+
+func TestNewConsoleFormatter(t *testing.T) {
+	tcases := []struct {
+		test    string
+		mode    ColorMode
+		noColor string
+		color   bool
+	}{
+		{test: "Auto disabled when not a terminal", mode: ColorModeAuto, color: false},
+		{test: "Auto disabled by NO_COLOR", mode: ColorModeAuto, noColor: "1", color: false},
+		{test: "Always", mode: ColorModeAlways, noColor: "1", color: true},
+		{test: "Never", mode: ColorModeNever, color: false},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tcase.noColor)
+			writer := strings.Builder{}
+			f := NewConsoleFormatter(&writer, tcase.mode)
+			if f.Color != tcase.color {
+				t.Errorf("Expected Color '%t', Received '%t'", tcase.color, f.Color)
+			}
+		})
+	}
+}
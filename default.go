@@ -23,6 +23,96 @@ func SetFlags(flags int) {
 	DefaultLogger.SetFlags(flags)
 }
 
+// Set stack-trace level of DefaultLogger
+func SetStackLevel(level LogLevel) {
+	DefaultLogger.SetStackLevel(level)
+}
+
+// Set keys whose values are redacted by DefaultLogger
+func SetRedactKeys(keys ...string) {
+	DefaultLogger.SetRedactKeys(keys...)
+}
+
+// Set scrubber of DefaultLogger
+func SetScrubber(s *Scrubber) {
+	DefaultLogger.SetScrubber(s)
+}
+
+// Set clock of DefaultLogger
+func SetClock(c Clock) {
+	DefaultLogger.SetClock(c)
+}
+
+// Set number of additional frames skipped by DefaultLogger when determining the caller
+func SetCallerSkip(n int) {
+	DefaultLogger.SetCallerSkip(n)
+}
+
+// Set how DefaultLogger records the caller of a message
+func SetCallerMode(mode CallerMode) {
+	DefaultLogger.SetCallerMode(mode)
+}
+
+// Set timestamp format of DefaultLogger
+func SetTimeFormat(format TimeFormat) {
+	DefaultLogger.SetTimeFormat(format)
+}
+
+// Add static fields to every line of DefaultLogger
+func AddFields(fields ...Field) {
+	DefaultLogger.AddFields(fields...)
+}
+
+// Add hook to DefaultLogger
+func AddHook(hook Hook) {
+	DefaultLogger.AddHook(hook)
+}
+
+// Set handler for hook errors of DefaultLogger
+func SetHookErrorHandler(handler ErrorHandler) {
+	DefaultLogger.SetHookErrorHandler(handler)
+}
+
+// Set handler for write errors of DefaultLogger
+func SetErrorHandler(handler ErrorHandler) {
+	DefaultLogger.SetErrorHandler(handler)
+}
+
+// Set fallback output of DefaultLogger
+func SetFallbackOutput(w io.Writer) {
+	DefaultLogger.SetFallbackOutput(w)
+}
+
+// Set format of DefaultLogger
+func SetFormat(format Format) {
+	DefaultLogger.SetFormat(format)
+}
+
+// Set formatter of DefaultLogger
+func SetFormatter(f Formatter) {
+	DefaultLogger.SetFormatter(f)
+}
+
+// Print message at any loglevel from DefaultLogger
+func Log(level LogLevel, v ...interface{}) {
+	DefaultLogger.callerLog(level, v...)
+}
+
+// Printf message at any loglevel from DefaultLogger
+func Logf(level LogLevel, format string, v ...interface{}) {
+	DefaultLogger.callerLogf(level, format, v...)
+}
+
+// Print message returned by fn from DefaultLogger, only calling it if level is Enabled
+func LogFunc(level LogLevel, fn func() string) {
+	DefaultLogger.callerLogFunc(level, fn)
+}
+
+// Reports whether DefaultLogger would write messages logged at level
+func Enabled(level LogLevel) bool {
+	return DefaultLogger.Enabled(level)
+}
+
 // Print debug message from DefaultLogger
 func Debug(v ...interface{}) {
 	DefaultLogger.callerDebug(v...)
@@ -64,5 +154,5 @@ func Errorf(format string, v ...interface{}) {
 }
 
 func init() {
-	DefaultLogger = New(os.Stderr)
+	DefaultLogger = New(os.Stderr, WithFormat(formatFromEnv(FormatText)))
 }
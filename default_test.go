@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"errors"
 	"log"
 	"regexp"
 	"strings"
@@ -202,3 +203,19 @@ func TestDefaultLogger(t *testing.T) {
 		}
 	})
 }
+
+func TestDefaultLoggerSetHookErrorHandler(t *testing.T) {
+	defer func(prev Logger) { DefaultLogger = prev }(DefaultLogger)
+	SetOutput(&strings.Builder{})
+	hookErr := errors.New("metrics unavailable")
+	AddHook(&recordingHook{levels: []LogLevel{LvError}, err: hookErr})
+
+	var received []error
+	SetHookErrorHandler(func(err error) {
+		received = append(received, err)
+	})
+	Error("failed")
+	if len(received) != 1 || !errors.Is(received[0].(*HookError).Err, hookErr) {
+		t.Errorf("Expected hook error to be handled, Received %v", received)
+	}
+}
@@ -0,0 +1,27 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+)
+
+// ErrorHandler is called with errors that occur while logging.
+type ErrorHandler func(err error)
+
+// WriteError is passed to the ErrorHandler when a line cannot be written to the output.
+type WriteError struct {
+	Err error
+}
+
+func (e *WriteError) Error() string {
+	return fmt.Sprintf("logger: write failed: %s", e.Err)
+}
+
+func (e *WriteError) Unwrap() error {
+	return e.Err
+}
+
+// Default ErrorHandler, writes the error to STDERR.
+func stderrErrorHandler(err error) {
+	fmt.Fprintln(os.Stderr, err)
+}
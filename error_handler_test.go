@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type failingWriter struct {
+	err error
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
+
+func TestLoggerWriteErrors(t *testing.T) {
+	writeErr := errors.New("disk full")
+
+	for _, format := range []Format{FormatText, FormatJSON} {
+		t.Run(format.String(), func(t *testing.T) {
+			logger_ := New(&failingWriter{err: writeErr}, WithFormat(format))
+			logger_.SetFlags(0)
+			var handled []error
+			logger_.SetErrorHandler(func(err error) { handled = append(handled, err) })
+
+			t.Run("Reports errors without fallback", func(t *testing.T) {
+				logger_.Error("error")
+				if len(handled) != 1 || !errors.Is(handled[0], writeErr) {
+					t.Fatalf("Expected write error to be handled. Received: %v", handled)
+				}
+				var writeError *WriteError
+				if !errors.As(handled[0], &writeError) {
+					t.Errorf("Expected *WriteError. Received: %#v", handled[0])
+				}
+				if logger_.FailedWrites() != 1 {
+					t.Errorf("Expected 1 failed write, Received %d", logger_.FailedWrites())
+				}
+			})
+
+			t.Run("Switches to fallback", func(t *testing.T) {
+				fallback := strings.Builder{}
+				logger_.SetFallbackOutput(&fallback)
+				logger_.Error("first")
+				logger_.Error("second")
+				if logger_.FailedWrites() != 2 {
+					t.Errorf("Expected output to stay switched to fallback. Failed writes: %d", logger_.FailedWrites())
+				}
+				if strings.Count(fallback.String(), "\n") != 2 || !strings.Contains(fallback.String(), "first") {
+					t.Errorf("Expected lines to be written to fallback. Received:\n%s", fallback.String())
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Key of Fields created by Err()
+const ErrKey = "error"
+
+// Maximum depth of an error's Unwrap() chain that is rendered.
+const maxErrorDepth = 32
+
+// ErrorFielder is implemented by errors that carry key/value context.
+// Its fields are rendered alongside the error's message and type.
+type ErrorFielder interface {
+	ErrorFields() []Field
+}
+
+// Err creates a Field for err.
+// Error fields are rendered with their full errors.Unwrap()/errors.Join() tree,
+// including each error's type and ErrorFields().
+//
+//	Ex.
+//	    log.Error("failed to load config", logger.Err(err))
+func Err(err error) Field {
+	return Field{Key: ErrKey, Value: err}
+}
+
+// errorNode is an error, and the errors it wraps.
+type errorNode struct {
+	Message string                 `json:"message"`
+	Type    string                 `json:"type"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	Causes  []errorNode            `json:"causes,omitempty"`
+
+	fields []Field // ordered Fields, for text
+}
+
+func newErrorNode(err error) errorNode {
+	if redacted, ok := err.(*redactedError); ok {
+		return redacted.node
+	}
+	return buildErrorNode(err, 0)
+}
+
+func buildErrorNode(err error, depth int) errorNode {
+	node := errorNode{Message: "<nil>", Type: fmt.Sprintf("%T", err)}
+	if isNil(err) {
+		return node
+	}
+	node.Message = errorMessage(err)
+	if fielder, ok := err.(ErrorFielder); ok {
+		node.fields = errorFields(fielder)
+	}
+	if len(node.fields) > 0 {
+		node.Fields = make(map[string]interface{}, len(node.fields))
+		for _, field := range node.fields {
+			node.Fields[field.Key] = field.Value
+		}
+	}
+	if depth >= maxErrorDepth {
+		return node
+	}
+
+	for _, cause := range errorCauses(err) {
+		if cause != nil {
+			node.Causes = append(node.Causes, buildErrorNode(cause, depth+1))
+		}
+	}
+	return node
+}
+
+// Reports whether err is nil, or a nil pointer/map/etc. of a type implementing error.
+func isNil(err error) bool {
+	if err == nil {
+		return true
+	}
+	rv := reflect.ValueOf(err)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface, reflect.UnsafePointer:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// Returns err.Error(), rendering a panic like fmt does.
+func errorMessage(err error) (msg string) {
+	defer func() {
+		if p := recover(); p != nil {
+			msg = fmt.Sprintf("%%!v(PANIC=Error method: %v)", p)
+		}
+	}()
+	return err.Error()
+}
+
+// Returns fielder.ErrorFields(), or a field describing the panic if it panics.
+func errorFields(fielder ErrorFielder) (fields []Field) {
+	defer func() {
+		if p := recover(); p != nil {
+			fields = []Field{F("error.fields", fmt.Sprintf("%%!v(PANIC=ErrorFields method: %v)", p))}
+		}
+	}()
+	return fielder.ErrorFields()
+}
+
+// Returns the errors wrapped by err (errors.Unwrap, or errors.Join), ignoring panics.
+func errorCauses(err error) (causes []error) {
+	defer func() {
+		if p := recover(); p != nil {
+			causes = nil
+		}
+	}()
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := wrapper.Unwrap(); cause != nil {
+			return []error{cause}
+		}
+	case interface{ Unwrap() []error }:
+		return wrapper.Unwrap()
+	}
+	return nil
+}
+
+// Reports whether the error has context beyond it's message.
+func (n *errorNode) hasDetail() bool {
+	return len(n.Causes) > 0 || len(n.fields) > 0
+}
+
+// Renders the error's types and fields, with causes separated by '->'.
+// Multiple causes (errors.Join) are grouped in parentheses, separated by '|'.
+//
+//	Ex.
+//	    *fmt.wrapError -> *fs.PathError{op=open path=/x} -> syscall.Errno
+func (n *errorNode) chain() string {
+	builder := strings.Builder{}
+	n.writeChain(&builder)
+	return builder.String()
+}
+
+func (n *errorNode) writeChain(builder *strings.Builder) {
+	builder.WriteString(n.Type)
+	if len(n.fields) > 0 {
+		builder.WriteByte('{')
+		for i, field := range n.fields {
+			if i > 0 {
+				builder.WriteByte(' ')
+			}
+			builder.WriteString(field.Key)
+			builder.WriteByte('=')
+			builder.WriteString(fieldString(field.Value))
+		}
+		builder.WriteByte('}')
+	}
+
+	switch len(n.Causes) {
+	case 0:
+		return
+	case 1:
+		builder.WriteString(" -> ")
+		n.Causes[0].writeChain(builder)
+	default:
+		builder.WriteString(" -> (")
+		for i := range n.Causes {
+			if i > 0 {
+				builder.WriteString(" | ")
+			}
+			n.Causes[i].writeChain(builder)
+		}
+		builder.WriteByte(')')
+	}
+}
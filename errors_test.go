@@ -0,0 +1,144 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type queryError struct {
+	query string
+	err   error
+}
+
+func (e *queryError) Error() string        { return "query failed: " + e.err.Error() }
+func (e *queryError) Unwrap() error        { return e.err }
+func (e *queryError) ErrorFields() []Field { return []Field{F("query", e.query)} }
+
+type joinedError struct {
+	errs []error
+}
+
+func (e *joinedError) Error() string   { return "multiple errors" }
+func (e *joinedError) Unwrap() []error { return e.errs }
+
+func TestErrorNode(t *testing.T) {
+	root := errors.New("timeout")
+	err := fmt.Errorf("load user: %w", &joinedError{errs: []error{
+		&queryError{query: "SELECT 1", err: root},
+		errors.New("cache miss"),
+	}})
+
+	node := newErrorNode(err)
+	t.Run("Renders chain", func(t *testing.T) {
+		expects := "*fmt.wrapError -> *logger.joinedError -> (*logger.queryError{query=SELECT 1} -> *errors.errorString | *errors.errorString)"
+		if node.chain() != expects {
+			t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", expects, node.chain())
+		}
+	})
+
+	t.Run("Renders JSON", func(t *testing.T) {
+		expects := `{"message":"load user: multiple errors","type":"*fmt.wrapError","causes":[` +
+			`{"message":"multiple errors","type":"*logger.joinedError","causes":[` +
+			`{"message":"query failed: timeout","type":"*logger.queryError","fields":{"query":"SELECT 1"},"causes":[{"message":"timeout","type":"*errors.errorString"}]},` +
+			`{"message":"cache miss","type":"*errors.errorString"}]}]}`
+		received := string(appendJSONValue(nil, err))
+		if received != expects {
+			t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", expects, received)
+		}
+	})
+}
+
+func TestLoggerErr(t *testing.T) {
+	t.Run("Plain error renders message only", func(t *testing.T) {
+		writer := strings.Builder{}
+		logger_ := New(&writer)
+		logger_.SetFlags(0)
+		logger_.Error("failed", Err(errors.New("boom")))
+		expects := "[ERROR] failed error=boom\n"
+		if writer.String() != expects {
+			t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", expects, writer.String())
+		}
+	})
+
+	t.Run("Wrapped error renders chain", func(t *testing.T) {
+		writer := strings.Builder{}
+		logger_ := New(&writer)
+		logger_.SetFlags(0)
+		err := fmt.Errorf("load: %w", &queryError{query: "SELECT 1", err: errors.New("timeout")})
+		logger_.Error("failed", Err(err))
+		expects := `[ERROR] failed error="load: query failed: timeout" error.chain="*fmt.wrapError -> *logger.queryError{query=SELECT 1} -> *errors.errorString"` + "\n"
+		if writer.String() != expects {
+			t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", expects, writer.String())
+		}
+	})
+}
+
+type panickyError struct{}
+
+func (e panickyError) Error() string        { panic("boom") }
+func (e panickyError) ErrorFields() []Field { panic("boom") }
+
+func TestLoggerErrNil(t *testing.T) {
+	var typedNil *queryError
+	tcases := []struct {
+		test      string
+		err       error
+		formatter Formatter
+		expect    string
+	}{
+		{test: "Typed nil text", err: typedNil, expect: "[ERROR] failed error=<nil>\n"},
+		{test: "Typed nil JSON", err: typedNil, formatter: &JSONFormatter{}, expect: `{"level":"ERROR","msg":"failed","error":{"message":"\u003cnil\u003e","type":"*logger.queryError"}}` + "\n"},
+		{test: "Nil JSON", err: nil, formatter: &JSONFormatter{}, expect: `{"level":"ERROR","msg":"failed","error":null}` + "\n"},
+		{
+			test:   "Panicking methods text",
+			err:    panickyError{},
+			expect: `[ERROR] failed error="%!v(PANIC=Error method: boom)" error.chain="logger.panickyError{error.fields=%!v(PANIC=ErrorFields method: boom)}"` + "\n",
+		},
+		{
+			test:      "Panicking methods JSON",
+			err:       panickyError{},
+			formatter: &JSONFormatter{},
+			expect:    `{"level":"ERROR","msg":"failed","error":{"message":"%!v(PANIC=Error method: boom)","type":"logger.panickyError","fields":{"error.fields":"%!v(PANIC=ErrorFields method: boom)"}}}` + "\n",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			writer := strings.Builder{}
+			logger_ := New(&writer)
+			logger_.SetFlags(0)
+			logger_.SetFormatter(tcase.formatter)
+			logger_.Error("failed", Err(tcase.err))
+			if writer.String() != tcase.expect {
+				t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", tcase.expect, writer.String())
+			}
+		})
+	}
+}
+
+// error with fields that cannot be encoded as JSON
+type callbackError struct {
+	callback func()
+	err      error
+}
+
+func (e *callbackError) Error() string        { return "callback failed" }
+func (e *callbackError) Unwrap() error        { return e.err }
+func (e *callbackError) ErrorFields() []Field { return []Field{F("cb", e.callback), F("id", 1)} }
+
+func TestLoggerErrJSONUnencodableFields(t *testing.T) {
+	cb := func() {}
+	err := &callbackError{callback: cb, err: &callbackError{callback: cb}}
+	writer := strings.Builder{}
+	logger_ := New(&writer)
+	logger_.SetFlags(0)
+	logger_.SetFormatter(&JSONFormatter{})
+	logger_.Error("failed", Err(err))
+	fields := `"fields":{"cb":"` + fieldString(cb) + `","id":1}`
+	expects := `{"level":"ERROR","msg":"failed","error":{"message":"callback failed","type":"*logger.callbackError",` + fields +
+		`,"causes":[{"message":"callback failed","type":"*logger.callbackError",` + fields + `}]}}` + "\n"
+	if writer.String() != expects {
+		t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", expects, writer.String())
+	}
+}
@@ -0,0 +1,109 @@
+package logger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Field is a key/value pair attached to a log message.
+// Fields passed as log arguments are rendered after the message instead of within it.
+//
+//	Ex.
+//	    log.Info("user logged in", logger.F("user", id))
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Create a Field
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Separates Fields from the other log arguments.
+// Lazy field values are resolved.
+func splitFields(v []interface{}) ([]interface{}, []Field) {
+	var args []interface{}
+	var fields []Field
+	for i, arg := range v {
+		field, ok := arg.(Field)
+		if !ok {
+			if args != nil {
+				args = append(args, arg)
+			}
+			continue
+		}
+		if args == nil {
+			args = make([]interface{}, i, len(v))
+			copy(args, v[:i])
+		}
+		if fn, ok := field.Value.(Lazy); ok {
+			field.Value = fn()
+		}
+		fields = append(fields, field)
+	}
+	if args == nil {
+		return v, nil
+	}
+	return args, fields
+}
+
+// Keys written by structured formatters (ex. JSONFormatter) for the Record itself.
+var reservedKeys = map[string]bool{"time": true, "level": true, "caller": true, "func": true, "msg": true, "stack": true}
+
+// Returns the key a field is written under by structured formatters.
+// Keys that collide with the Record's own keys are prefixed by 'fields.' (ex. "fields.level").
+func fieldKey(key string) string {
+	if reservedKeys[key] {
+		return "fields." + key
+	}
+	return key
+}
+
+// Renders a Field's value as a string
+func fieldString(value interface{}) string {
+	return fmt.Sprint(value)
+}
+
+// Renders a Field's value as text, quoting it if necessary.
+func fieldText(value interface{}) string {
+	text := fieldString(value)
+	if text == "" || strings.ContainsAny(text, " \t\r\n\"=") {
+		return strconv.Quote(text)
+	}
+	return text
+}
+
+// textField is a Field rendered as text
+type textField struct {
+	Key  string
+	Text string
+}
+
+// Renders fields as text.
+// errors are expanded into their message, and a '<key>.chain' field describing their wrapped errors.
+func textFields(fields []Field) []textField {
+	rendered := make([]textField, 0, len(fields))
+	for _, field := range fields {
+		rendered = append(rendered, textField{Key: field.Key, Text: fieldText(field.Value)})
+		if err, ok := field.Value.(error); ok {
+			node := newErrorNode(err)
+			if node.hasDetail() {
+				rendered = append(rendered, textField{Key: field.Key + ".chain", Text: fieldText(node.chain())})
+			}
+		}
+	}
+	return rendered
+}
+
+// Appends fields as ' key=value' pairs
+func appendFields(buf []byte, fields []Field) []byte {
+	for _, field := range textFields(fields) {
+		buf = append(buf, ' ')
+		buf = append(buf, field.Key...)
+		buf = append(buf, '=')
+		buf = append(buf, field.Text...)
+	}
+	return buf
+}
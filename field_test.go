@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitFields(t *testing.T) {
+	t.Run("Separates fields from arguments", func(t *testing.T) {
+		args, fields := splitFields([]interface{}{"a", F("key", "val"), 1, F("lazy", Lazy(func() interface{} { return 2 }))})
+		if !reflect.DeepEqual(args, []interface{}{"a", 1}) {
+			t.Errorf("Expected '[a 1]', Received '%v'", args)
+		}
+		expects := []Field{F("key", "val"), F("lazy", 2)}
+		if !reflect.DeepEqual(fields, expects) {
+			t.Errorf("Expected '%v', Received '%v'", expects, fields)
+		}
+	})
+
+	t.Run("Arguments without fields are returned as-is", func(t *testing.T) {
+		v := []interface{}{"a", 1}
+		args, fields := splitFields(v)
+		if &args[0] != &v[0] || fields != nil {
+			t.Errorf("Expected arguments to be returned unmodified")
+		}
+	})
+}
+
+func TestAppendFields(t *testing.T) {
+	tcases := []struct {
+		test   string
+		fields []Field
+		expect string
+	}{
+		{test: "Plain", fields: []Field{F("a", 1), F("b", "two")}, expect: " a=1 b=two"},
+		{test: "Quotes whitespace", fields: []Field{F("a", "x y")}, expect: ` a="x y"`},
+		{test: "Quotes empty", fields: []Field{F("a", "")}, expect: ` a=""`},
+		{test: "Quotes quotes", fields: []Field{F("a", `x"=`)}, expect: ` a="x\"="`},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			received := string(appendFields(nil, tcase.fields))
+			if received != tcase.expect {
+				t.Errorf("Expected '%s', Received '%s'", tcase.expect, received)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// Environment variable that selects the output Format.
+// It overrides FormatAuto, and is used by DefaultLogger when set.
+const FormatEnv = "LOG_FORMAT"
+
+// Format selects one of the builtin Formatters.
+type Format int8
+
+const (
+	FormatText    Format = iota // '[LEVEL] ' prefixed lines, like the log package (default)
+	FormatConsole               // ConsoleFormatter, coloured if writing to a terminal
+	FormatJSON                  // JSONFormatter
+	FormatAuto                  // FormatEnv if set, otherwise FormatConsole for terminals and FormatJSON for everything else
+	FormatLogfmt                // LogfmtFormatter
+
+	formatCustom Format = -1 // set by SetFormatter()
+)
+
+// Parses a Format from it's name (ex. "json").
+func ParseFormat(name string) (Format, bool) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "text":
+		return FormatText, true
+	case "console":
+		return FormatConsole, true
+	case "json":
+		return FormatJSON, true
+	case "auto":
+		return FormatAuto, true
+	case "logfmt":
+		return FormatLogfmt, true
+	}
+	return FormatText, false
+}
+
+func (format Format) String() string {
+	switch format {
+	case FormatText:
+		return "text"
+	case FormatConsole:
+		return "console"
+	case FormatJSON:
+		return "json"
+	case FormatAuto:
+		return "auto"
+	case FormatLogfmt:
+		return "logfmt"
+	}
+	return "custom"
+}
+
+// Returns the Format named by FormatEnv, or fallback if unset/invalid.
+func formatFromEnv(fallback Format) Format {
+	if format, ok := ParseFormat(os.Getenv(FormatEnv)); ok {
+		return format
+	}
+	return fallback
+}
+
+// Returns the Formatter for format when writing to w.
+// FormatText is written like the log package, and has no formatter.
+func newFormatter(format Format, w io.Writer) Formatter {
+	if format == FormatAuto {
+		format = formatFromEnv(FormatAuto)
+		if format == FormatAuto {
+			if isTerminal(w) {
+				format = FormatConsole
+			} else {
+				format = FormatJSON
+			}
+		}
+	}
+
+	switch format {
+	case FormatConsole:
+		return NewConsoleFormatter(w, ColorModeAuto)
+	case FormatJSON:
+		return &JSONFormatter{}
+	case FormatLogfmt:
+		return &LogfmtFormatter{}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	tcases := []struct {
+		name   string
+		format Format
+		ok     bool
+	}{
+		{name: "text", format: FormatText, ok: true},
+		{name: "Console", format: FormatConsole, ok: true},
+		{name: " json ", format: FormatJSON, ok: true},
+		{name: "auto", format: FormatAuto, ok: true},
+		{name: "logfmt", format: FormatLogfmt, ok: true},
+		{name: "xml", format: FormatText, ok: false},
+	}
+	for _, tcase := range tcases {
+		format, ok := ParseFormat(tcase.name)
+		if format != tcase.format || ok != tcase.ok {
+			t.Errorf("ParseFormat('%s') Expected (%s, %t), Received (%s, %t)", tcase.name, tcase.format, tcase.ok, format, ok)
+		}
+	}
+}
+
+func TestNewFormatter(t *testing.T) {
+	tcases := []struct {
+		test   string
+		format Format
+		env    string
+		expect Formatter
+	}{
+		{test: "Text", format: FormatText, expect: nil},
+		{test: "Console", format: FormatConsole, expect: &ConsoleFormatter{}},
+		{test: "JSON", format: FormatJSON, expect: &JSONFormatter{}},
+		{test: "Logfmt", format: FormatLogfmt, expect: &LogfmtFormatter{}},
+		{test: "Auto without terminal", format: FormatAuto, expect: &JSONFormatter{}},
+		{test: "Auto with env override", format: FormatAuto, env: "console", expect: &ConsoleFormatter{}},
+		{test: "Env does not override explicit format", format: FormatText, env: "json", expect: nil},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			t.Setenv(FormatEnv, tcase.env)
+			writer := strings.Builder{}
+			formatter := newFormatter(tcase.format, &writer)
+			if !reflect.DeepEqual(formatter, tcase.expect) {
+				t.Errorf("Expected '%#v', Received '%#v'", tcase.expect, formatter)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package logger
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formatter renders a Record as a line of output.
+// flags are the log.Lstdflags style flags set on the Logger.
+type Formatter interface {
+	Format(r *Record, flags int) []byte
+}
+
+// Appends the timestamp requested by flags, like log.Logger (without trailing space).
+func appendTime(buf []byte, t time.Time, flags int) []byte {
+	if flags&log.LUTC != 0 {
+		t = t.UTC()
+	}
+	layout := ""
+	if flags&log.Ldate != 0 {
+		layout = "2006/01/02"
+	}
+	if flags&(log.Ltime|log.Lmicroseconds) != 0 {
+		if layout != "" {
+			layout += " "
+		}
+		layout += "15:04:05"
+		if flags&log.Lmicroseconds != 0 {
+			layout += ".000000"
+		}
+	}
+	if layout == "" {
+		return buf
+	}
+	return t.AppendFormat(buf, layout)
+}
+
+// Appends 'file:line' requested by flags, like log.Logger (without trailing ': ').
+func appendCaller(buf []byte, file string, line int, flags int) []byte {
+	if flags&(log.Lshortfile|log.Llongfile) == 0 || file == "" {
+		return buf
+	}
+	if flags&log.Lshortfile != 0 {
+		file = file[strings.LastIndexByte(file, '/')+1:]
+	}
+	buf = append(buf, file...)
+	buf = append(buf, ':')
+	return strconv.AppendInt(buf, int64(line), 10)
+}
+
+// Appends the record's caller, like appendCaller, preceded by it's function when set (ex. 'main.run main.go:23').
+func appendFuncCaller(buf []byte, r *Record, flags int) []byte {
+	caller := appendCaller(nil, r.File, r.Line, flags)
+	if len(caller) == 0 {
+		return buf
+	}
+	if r.Function != "" {
+		buf = append(buf, r.Function...)
+		buf = append(buf, ' ')
+	}
+	return append(buf, caller...)
+}
+
+// textFormatter renders lines like log.Logger, with a '[LEVEL] ' prefix (FormatText).
+//
+//	Ex.
+//	    [WARN ] 2009/01/23 01:23:23 /src/main.go:23: disk almost full free=2%
+type textFormatter struct{}
+
+func (textFormatter) Format(r *Record, flags int) []byte {
+	prefix := r.Level.prefix()
+	var buf []byte
+	if flags&log.Lmsgprefix == 0 {
+		buf = append(buf, prefix...)
+	}
+	if timestamp := appendTimestamp(buf, r, flags); len(timestamp) > len(buf) {
+		buf = append(timestamp, ' ')
+	}
+	if caller := appendFuncCaller(buf, r, flags); len(caller) > len(buf) {
+		buf = append(caller, ": "...)
+	}
+	if flags&log.Lmsgprefix != 0 {
+		buf = append(buf, prefix...)
+	}
+	buf = append(buf, r.String()...)
+	if len(buf) == 0 || buf[len(buf)-1] != '\n' {
+		buf = append(buf, '\n')
+	}
+	return buf
+}
+
+// Renders r with f, or like log.Logger when f is nil.
+func formatRecord(f Formatter, r *Record, flags int) []byte {
+	if f == nil {
+		f = textFormatter{}
+	}
+	return f.Format(r, flags)
+}
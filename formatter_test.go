@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"log"
+	"testing"
+	"time"
+)
+
+func TestTextFormatter(t *testing.T) {
+	r := Record{
+		Time:    time.Date(2009, 1, 23, 1, 23, 23, 123456000, time.Local),
+		Level:   LvWarn,
+		Message: "disk almost full",
+		File:    "/src/main.go",
+		Line:    23,
+		Fields:  []Field{F("free", "2%")},
+	}
+	tcases := []struct {
+		test   string
+		flags  int
+		expect string
+	}{
+		{
+			test:   "Default flags",
+			flags:  defaultLogFlags,
+			expect: "[WARN ] 2009/01/23 01:23:23 /src/main.go:23: disk almost full free=2%\n",
+		},
+		{
+			test:   "Short file with microseconds",
+			flags:  log.Ltime | log.Lmicroseconds | log.Lshortfile,
+			expect: "[WARN ] 01:23:23.123456 main.go:23: disk almost full free=2%\n",
+		},
+		{
+			test:   "Message prefix",
+			flags:  log.Ldate | log.Lmsgprefix,
+			expect: "2009/01/23 [WARN ] disk almost full free=2%\n",
+		},
+		{
+			test:   "No flags",
+			flags:  0,
+			expect: "[WARN ] disk almost full free=2%\n",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			received := string(textFormatter{}.Format(&r, tcase.flags))
+			if received != tcase.expect {
+				t.Errorf("Expected %q, Received %q", tcase.expect, received)
+			}
+		})
+	}
+}
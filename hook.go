@@ -0,0 +1,37 @@
+package logger
+
+import "fmt"
+
+// Hook is called for each Record written at one of it's Levels(),
+// after sensitive data is redacted and before it is formatted.
+//
+// Fire may modify the Record (ex. add Fields).
+// Fields added by a hook are redacted before the Record is formatted.
+type Hook interface {
+	Levels() []LogLevel
+	Fire(r *Record) error
+}
+
+// HookError is passed to the hook ErrorHandler when a Hook fails.
+type HookError struct {
+	Hook Hook
+	Err  error
+}
+
+func (e *HookError) Error() string {
+	return fmt.Sprintf("logger: hook %T failed: %s", e.Hook, e.Err)
+}
+
+func (e *HookError) Unwrap() error {
+	return e.Err
+}
+
+// Reports whether hook should be fired for level
+func hookFires(hook Hook, level LogLevel) bool {
+	for _, hookLevel := range hook.Levels() {
+		if hookLevel == level {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type recordingHook struct {
+	levels []LogLevel
+	fired  []Record
+	err    error
+}
+
+func (h *recordingHook) Levels() []LogLevel {
+	return h.levels
+}
+
+func (h *recordingHook) Fire(r *Record) error {
+	h.fired = append(h.fired, *r)
+	r.Fields = append(r.Fields, F("hooked", true))
+	return h.err
+}
+
+// hook that adds a field to each record
+type fieldHook struct {
+	levels []LogLevel
+	field  Field
+}
+
+func (h *fieldHook) Levels() []LogLevel {
+	return h.levels
+}
+
+func (h *fieldHook) Fire(r *Record) error {
+	r.Fields = append(r.Fields, h.field)
+	return nil
+}
+
+func TestLoggerHooks(t *testing.T) {
+	t.Run("Fires for hook levels after filtering", func(t *testing.T) {
+		writer := strings.Builder{}
+		logger_ := New(&writer)
+		logger_.SetFlags(0)
+		logger_.SetLevel(LvInfo)
+		hook := recordingHook{levels: []LogLevel{LvError, LvDebug}}
+		logger_.AddHook(&hook)
+
+		logger_.Error("error")
+		logger_.Warn("warn")
+		logger_.Debug("debug")
+		if len(hook.fired) != 1 || hook.fired[0].Message != "error" {
+			t.Errorf("Expected hook to fire once for error. Received: %v", hook.fired)
+		}
+		if hook.fired[0].Time.IsZero() {
+			t.Error("Expected hook to receive record with time")
+		}
+		expects := "[ERROR] error hooked=true\n[WARN ] warn\n"
+		if writer.String() != expects {
+			t.Errorf("Expected hooks to be able to modify fields.\nExpected:\n'%s'\nReceived:\n'%s'", expects, writer.String())
+		}
+	})
+
+	t.Run("Hooks receive redacted records", func(t *testing.T) {
+		writer := strings.Builder{}
+		logger_ := New(&writer)
+		logger_.SetRedactKeys("password")
+		hook := recordingHook{levels: []LogLevel{LvError}}
+		logger_.AddHook(&hook)
+
+		logger_.Error("login", F("password", "hunter2"))
+		if hook.fired[0].Fields[0].Value != Redacted {
+			t.Errorf("Expected redacted field. Received: %v", hook.fired[0].Fields)
+		}
+	})
+
+	t.Run("Fields added by hooks are redacted", func(t *testing.T) {
+		writer := strings.Builder{}
+		logger_ := New(&writer)
+		logger_.SetFlags(0)
+		logger_.SetRedactKeys("password")
+		logger_.AddHook(&fieldHook{levels: []LogLevel{LvWarn}, field: F("password", "hunter2")})
+
+		logger_.Warn("x")
+		expects := "[WARN ] x password=[REDACTED]\n"
+		if writer.String() != expects {
+			t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", expects, writer.String())
+		}
+	})
+
+	t.Run("Errors are passed to the hook error handler", func(t *testing.T) {
+		writer := strings.Builder{}
+		logger_ := New(&writer)
+		hookErr := errors.New("metrics unavailable")
+		hook := recordingHook{levels: []LogLevel{LvError}, err: hookErr}
+		logger_.AddHook(&hook)
+		var handled []error
+		logger_.SetHookErrorHandler(func(err error) { handled = append(handled, err) })
+
+		logger_.Error("error")
+		if len(handled) != 1 || !errors.Is(handled[0], hookErr) {
+			t.Fatalf("Expected hook error to be handled. Received: %v", handled)
+		}
+		var hookError *HookError
+		if !errors.As(handled[0], &hookError) || hookError.Hook != &hook {
+			t.Errorf("Expected *HookError referencing the hook. Received: %#v", handled[0])
+		}
+		if writer.Len() == 0 {
+			t.Error("Expected message to be written despite hook error")
+		}
+	})
+}
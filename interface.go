@@ -8,6 +8,7 @@ type Interface interface {
 	SetFlags(flags int)
 	Flags() int
 	Level() LogLevel
+	Enabled(level LogLevel) bool
 	Debug(v ...interface{})
 	Info(v ...interface{})
 	Warn(v ...interface{})
@@ -16,4 +17,7 @@ type Interface interface {
 	Infof(format string, v ...interface{})
 	Warnf(format string, v ...interface{})
 	Errorf(format string, v ...interface{})
+	Log(level LogLevel, v ...interface{})
+	Logf(level LogLevel, format string, v ...interface{})
+	LogFunc(level LogLevel, fn func() string)
 }
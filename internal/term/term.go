@@ -0,0 +1,2 @@
+// Detects whether a file descriptor refers to a terminal, without cgo.
+package term
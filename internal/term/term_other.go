@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd && !windows
+
+package term
+
+// IsTerminal reports whether fd refers to a terminal.
+// Terminals are not detected on this platform.
+func IsTerminal(fd uintptr) bool {
+	return false
+}
@@ -0,0 +1,31 @@
+package term
+
+import (
+	"os"
+	"testing"
+)
+
+func TestIsTerminal(t *testing.T) {
+	t.Run("Pipe is not a terminal", func(t *testing.T) {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Close()
+		defer w.Close()
+		if IsTerminal(w.Fd()) {
+			t.Error("Expected pipe not to be a terminal")
+		}
+	})
+
+	t.Run("File is not a terminal", func(t *testing.T) {
+		f, err := os.CreateTemp(t.TempDir(), "term")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		if IsTerminal(f.Fd()) {
+			t.Error("Expected file not to be a terminal")
+		}
+	})
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package term
+
+import "syscall"
+
+// IsTerminal reports whether fd refers to a console.
+func IsTerminal(fd uintptr) bool {
+	var mode uint32
+	return syscall.GetConsoleMode(syscall.Handle(fd), &mode) == nil
+}
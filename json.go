@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// JSONFormatter renders each Record as a single line JSON object.
+// Fields are written as top-level keys after "time", "level", "caller" and "msg",
+// and a captured stack is written as an array of frames under "stack".
+// Fields with one of these keys are prefixed by 'fields.' (ex. "fields.level").
+//
+//	Ex.
+//	    {"time":"2009-01-23T01:23:23Z","level":"WARN","caller":"main.go:23","msg":"disk almost full","free":"2%"}
+type JSONFormatter struct{}
+
+func (f *JSONFormatter) Format(r *Record, flags int) []byte {
+	buf := []byte{'{'}
+	switch {
+	case r.timeFormat == TimeUnixMilli:
+		buf = appendJSONKey(buf, "time")
+		buf = appendTimestamp(buf, r, flags)
+	case r.timeFormat != TimeFlags:
+		buf = appendJSONKey(buf, "time")
+		buf = appendJSONValue(buf, string(appendTimestamp(nil, r, flags)))
+	case flags&(log.Ldate|log.Ltime|log.Lmicroseconds) != 0:
+		t := r.Time
+		if flags&log.LUTC != 0 {
+			t = t.UTC()
+		}
+		buf = appendJSONKey(buf, "time")
+		buf = appendJSONValue(buf, t.Format(time.RFC3339Nano))
+	}
+	buf = appendJSONKey(buf, "level")
+	buf = appendJSONValue(buf, r.Level.String())
+	if caller := appendCaller(nil, r.File, r.Line, flags); len(caller) > 0 {
+		buf = appendJSONKey(buf, "caller")
+		buf = appendJSONValue(buf, string(caller))
+		if r.Function != "" {
+			buf = appendJSONKey(buf, "func")
+			buf = appendJSONValue(buf, r.Function)
+		}
+	}
+	buf = appendJSONKey(buf, "msg")
+	buf = appendJSONValue(buf, r.Message)
+	for _, field := range r.Fields {
+		buf = appendJSONKey(buf, fieldKey(field.Key))
+		buf = appendJSONValue(buf, field.Value)
+	}
+	if len(r.Stack) > 0 {
+		stack := make([]string, len(r.Stack))
+		for i, frame := range r.Stack {
+			stack[i] = frame.String()
+		}
+		buf = appendJSONKey(buf, "stack")
+		buf = appendJSONValue(buf, stack)
+	}
+	return append(buf, '}', '\n')
+}
+
+// Appends '"key":', preceded by a comma if it is not the first key
+func appendJSONKey(buf []byte, key string) []byte {
+	if buf[len(buf)-1] != '{' {
+		buf = append(buf, ',')
+	}
+	buf = appendJSONValue(buf, key)
+	return append(buf, ':')
+}
+
+// Appends value encoded as JSON.
+// errors are written as an object with their message, type, fields and causes,
+// and values that cannot be encoded are written as strings.
+func appendJSONValue(buf []byte, value interface{}) []byte {
+	if err, ok := value.(error); ok {
+		value = jsonErrorNode(newErrorNode(err))
+	}
+	encoded, err := json.Marshal(value)
+	if node, ok := value.(errorNode); ok && err != nil {
+		encoded, _ = json.Marshal(errorNode{Message: node.Message, Type: node.Type})
+	} else if err != nil {
+		encoded, _ = json.Marshal(fieldString(value))
+	}
+	return append(buf, encoded...)
+}
+
+// Returns node, with field values that cannot be encoded as JSON replaced by their string.
+// Fields are copied before they are replaced, the node may belong to a redacted error.
+func jsonErrorNode(node errorNode) errorNode {
+	var fields map[string]interface{}
+	for key, value := range node.Fields {
+		if _, err := json.Marshal(value); err == nil {
+			continue
+		}
+		if fields == nil {
+			fields = make(map[string]interface{}, len(node.Fields))
+			for key, value := range node.Fields {
+				fields[key] = value
+			}
+		}
+		fields[key] = fieldString(value)
+	}
+	if fields != nil {
+		node.Fields = fields
+	}
+	if len(node.Causes) > 0 {
+		causes := make([]errorNode, len(node.Causes))
+		for i, cause := range node.Causes {
+			causes[i] = jsonErrorNode(cause)
+		}
+		node.Causes = causes
+	}
+	return node
+}
@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"errors"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestJSONFormatter(t *testing.T) {
+	r := Record{
+		Time:    time.Date(2009, 1, 23, 1, 23, 23, 0, time.UTC),
+		Level:   LvWarn,
+		Message: `disk "almost" full`,
+		File:    "/src/main.go",
+		Line:    23,
+		Fields: []Field{
+			F("free", 2),
+			F("mounts", []string{"/", "/home"}),
+			F("err", errors.New("boom")),
+			F("fn", func() {}),
+		},
+	}
+
+	tcases := []struct {
+		test   string
+		flags  int
+		expect string
+	}{
+		{
+			test:   "All flags",
+			flags:  log.LstdFlags | log.Lshortfile,
+			expect: `{"time":"2009-01-23T01:23:23Z","level":"WARN","caller":"main.go:23","msg":"disk \"almost\" full","free":2,"mounts":["/","/home"],"err":{"message":"boom","type":"*errors.errorString"},"fn":"` + fieldString(r.Fields[3].Value) + `"}` + "\n",
+		},
+		{
+			test:   "No flags",
+			flags:  0,
+			expect: `{"level":"WARN","msg":"disk \"almost\" full","free":2,"mounts":["/","/home"],"err":{"message":"boom","type":"*errors.errorString"},"fn":"` + fieldString(r.Fields[3].Value) + `"}` + "\n",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			f := JSONFormatter{}
+			received := string(f.Format(&r, tcase.flags))
+			if received != tcase.expect {
+				t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", tcase.expect, received)
+			}
+		})
+	}
+}
+
+func TestJSONFormatterReservedKeys(t *testing.T) {
+	r := Record{
+		Level:   LvInfo,
+		Message: "hi",
+		Fields:  []Field{F("msg", "dup"), F("level", "x"), F("user", "alice")},
+	}
+	f := JSONFormatter{}
+	expect := `{"level":"INFO","msg":"hi","fields.msg":"dup","fields.level":"x","user":"alice"}` + "\n"
+	if received := string(f.Format(&r, 0)); received != expect {
+		t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", expect, received)
+	}
+}
+
+func TestJSONFormatterStack(t *testing.T) {
+	r := Record{
+		Level:   LvError,
+		Message: "error",
+		Stack: []Frame{
+			{Function: "main.load", File: "/src/main.go", Line: 12},
+			{Function: "main.main", File: "/src/main.go", Line: 5},
+		},
+	}
+	f := JSONFormatter{}
+	expects := `{"level":"ERROR","msg":"error","stack":["main.load /src/main.go:12","main.main /src/main.go:5"]}` + "\n"
+	received := string(f.Format(&r, 0))
+	if received != expects {
+		t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", expects, received)
+	}
+}
+
+func TestJSONFormatterFunction(t *testing.T) {
+	r := Record{Level: LvWarn, Message: "warn", File: "db/conn.go", Line: 23, Function: "db.(*Conn).Query"}
+	f := JSONFormatter{}
+	expect := `{"level":"WARN","caller":"db/conn.go:23","func":"db.(*Conn).Query","msg":"warn"}` + "\n"
+	if received := string(f.Format(&r, log.Llongfile)); received != expect {
+		t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", expect, received)
+	}
+}
@@ -0,0 +1,29 @@
+package logger
+
+// Lazy wraps a log argument that is expensive to compute.
+// It is only evaluated if the message will actually be written.
+//
+//	Ex.
+//	    log.Debugf("state: %v", logger.Lazy(func() interface{} { return expensiveDump() }))
+type Lazy func() interface{}
+
+// Returns v, with Lazy arguments replaced by their values.
+// v is only copied if it contains a Lazy argument.
+func resolveLazy(v []interface{}) []interface{} {
+	var resolved []interface{}
+	for i, arg := range v {
+		fn, ok := arg.(Lazy)
+		if !ok {
+			continue
+		}
+		if resolved == nil {
+			resolved = make([]interface{}, len(v))
+			copy(resolved, v)
+		}
+		resolved[i] = fn()
+	}
+	if resolved == nil {
+		return v
+	}
+	return resolved
+}
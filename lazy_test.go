@@ -0,0 +1,27 @@
+package logger
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveLazy(t *testing.T) {
+	t.Run("Evaluates Lazy arguments", func(t *testing.T) {
+		v := []interface{}{"a", Lazy(func() interface{} { return 1 })}
+		resolved := resolveLazy(v)
+		if !reflect.DeepEqual(resolved, []interface{}{"a", 1}) {
+			t.Errorf("Expected '[a 1]', Received '%v'", resolved)
+		}
+		if _, ok := v[1].(Lazy); !ok {
+			t.Error("Expected original arguments to be left unmodified")
+		}
+	})
+
+	t.Run("Returns arguments without Lazy values as-is", func(t *testing.T) {
+		v := []interface{}{"a", 1}
+		resolved := resolveLazy(v)
+		if &resolved[0] != &v[0] {
+			t.Error("Expected arguments without Lazy values not to be copied")
+		}
+	})
+}
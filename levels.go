@@ -1,5 +1,11 @@
 package logger
 
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
 type LogLevel int8
 
 // Enum of LogLevels.
@@ -10,3 +16,98 @@ const (
 	LvInfo
 	LvDebug
 )
+
+// Color is an ANSI SGR parameter string (ex. "1;31") used when a level is rendered in colour.
+type Color string
+
+// Common Colors.
+const (
+	ColorNone    Color = ""
+	ColorRed     Color = "31"
+	ColorGreen   Color = "32"
+	ColorYellow  Color = "33"
+	ColorBlue    Color = "34"
+	ColorMagenta Color = "35"
+	ColorCyan    Color = "36"
+	ColorGray    Color = "90"
+)
+
+var (
+	ErrInvalidLevel = errors.New("loglevel must be greater than LvNone")
+	ErrLevelExists  = errors.New("loglevel is already registered")
+	ErrInvalidLabel = errors.New("loglevel label must not be empty")
+)
+
+type levelSpec struct {
+	label string
+	color Color
+}
+
+var (
+	levelsLock = &sync.RWMutex{} // read on every line, written only by RegisterLevel
+	levels     = map[LogLevel]levelSpec{
+		LvError: {label: "ERROR", color: ColorRed},
+		LvWarn:  {label: "WARN", color: ColorYellow},
+		LvInfo:  {label: "INFO", color: ColorGreen},
+		LvDebug: {label: "DEBUG", color: ColorBlue},
+	}
+)
+
+// RegisterLevel adds a custom LogLevel.
+//
+// The numeric value determines its ordering, a level is written when it is <= the logger's Level().
+// (ex. a NOTICE level between LvWarn and LvInfo could be 25).
+// color is optional, pass ColorNone to render it without colour.
+func RegisterLevel(level LogLevel, label string, color Color) error {
+	if level <= LvNone {
+		return ErrInvalidLevel
+	}
+	if label == "" {
+		return ErrInvalidLabel
+	}
+
+	levelsLock.Lock()
+	defer levelsLock.Unlock()
+	if spec, ok := levels[level]; ok {
+		return fmt.Errorf("%w: %d (%s)", ErrLevelExists, level, spec.label)
+	}
+	levels[level] = levelSpec{label: label, color: color}
+	return nil
+}
+
+// unregisterLevel removes a custom LogLevel (used to reset state between tests).
+func unregisterLevel(level LogLevel) {
+	levelsLock.Lock()
+	defer levelsLock.Unlock()
+	delete(levels, level)
+}
+
+func lookupLevel(level LogLevel) (levelSpec, bool) {
+	levelsLock.RLock()
+	defer levelsLock.RUnlock()
+	spec, ok := levels[level]
+	return spec, ok
+}
+
+// Label returned for a LogLevel (ex. "ERROR").
+// Unregistered levels are rendered as "LEVEL(n)".
+func (level LogLevel) String() string {
+	if spec, ok := lookupLevel(level); ok {
+		return spec.label
+	}
+	if level == LvNone {
+		return "NONE"
+	}
+	return fmt.Sprintf("LEVEL(%d)", level)
+}
+
+// Color a LogLevel is rendered with, if any.
+func (level LogLevel) Color() Color {
+	spec, _ := lookupLevel(level)
+	return spec.color
+}
+
+// prefix written before each line logged at this level (ex. "[INFO ] ")
+func (level LogLevel) prefix() string {
+	return fmt.Sprintf("[%-5s] ", level.String())
+}
@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegisterLevel(t *testing.T) {
+	t.Run("Registers label and color", func(t *testing.T) {
+		lvNotice := LogLevel(25)
+		t.Cleanup(func() { unregisterLevel(lvNotice) })
+
+		err := RegisterLevel(lvNotice, "NOTICE", ColorCyan)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if lvNotice.String() != "NOTICE" {
+			t.Errorf("Expected 'NOTICE', Received '%s'", lvNotice.String())
+		}
+		if lvNotice.Color() != ColorCyan {
+			t.Errorf("Expected '%s', Received '%s'", ColorCyan, lvNotice.Color())
+		}
+	})
+
+	t.Run("Rejects invalid levels", func(t *testing.T) {
+		tcases := []struct {
+			test  string
+			level LogLevel
+			label string
+			err   error
+		}{
+			{test: "LvNone", level: LvNone, label: "NOPE", err: ErrInvalidLevel},
+			{test: "Negative", level: -1, label: "NOPE", err: ErrInvalidLevel},
+			{test: "Builtin", level: LvInfo, label: "NOPE", err: ErrLevelExists},
+			{test: "Empty Label", level: 26, label: "", err: ErrInvalidLabel},
+		}
+		for _, tcase := range tcases {
+			t.Run(tcase.test, func(t *testing.T) {
+				err := RegisterLevel(tcase.level, tcase.label, ColorNone)
+				if !errors.Is(err, tcase.err) {
+					t.Errorf("Expected '%v', Received '%v'", tcase.err, err)
+				}
+			})
+		}
+	})
+
+	t.Run("Unregistered levels have a placeholder label", func(t *testing.T) {
+		if LogLevel(99).String() != "LEVEL(99)" {
+			t.Errorf("Expected 'LEVEL(99)', Received '%s'", LogLevel(99).String())
+		}
+	})
+}
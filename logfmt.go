@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// LogfmtFormatter renders each Record as a line of key=value pairs (logfmt).
+// Fields are written after "time", "level", "caller" and "msg",
+// and a captured stack is written under "stack", one frame per line.
+// Fields with one of these keys are prefixed by 'fields.' (ex. "fields.level"),
+// and spaces, '=' and '"' in keys are replaced by '_'.
+//
+//	Ex.
+//	    time=2009-01-23T01:23:23Z level=warn caller=main.go:23 msg="disk almost full" free=2%
+type LogfmtFormatter struct{}
+
+func (f *LogfmtFormatter) Format(r *Record, flags int) []byte {
+	var buf []byte
+	switch {
+	case r.timeFormat != TimeFlags:
+		buf = appendLogfmt(buf, "time", string(appendTimestamp(nil, r, flags)))
+	case flags&(log.Ldate|log.Ltime|log.Lmicroseconds) != 0:
+		t := r.Time
+		if flags&log.LUTC != 0 {
+			t = t.UTC()
+		}
+		buf = appendLogfmt(buf, "time", t.Format(time.RFC3339Nano))
+	}
+	buf = appendLogfmt(buf, "level", strings.ToLower(r.Level.String()))
+	if caller := appendCaller(nil, r.File, r.Line, flags); len(caller) > 0 {
+		buf = appendLogfmt(buf, "caller", string(caller))
+		if r.Function != "" {
+			buf = appendLogfmt(buf, "func", r.Function)
+		}
+	}
+	buf = appendLogfmt(buf, "msg", r.Message)
+	for _, field := range textFields(r.Fields) {
+		buf = append(buf, ' ')
+		buf = append(buf, logfmtKey(fieldKey(field.Key))...)
+		buf = append(buf, '=')
+		buf = append(buf, field.Text...)
+	}
+	if len(r.Stack) > 0 {
+		stack := make([]string, len(r.Stack))
+		for i, frame := range r.Stack {
+			stack[i] = frame.String()
+		}
+		buf = appendLogfmt(buf, "stack", strings.Join(stack, "\n"))
+	}
+	return append(buf, '\n')
+}
+
+// Appends a 'key=value' pair, separated from the previous pair by a space.
+func appendLogfmt(buf []byte, key string, value string) []byte {
+	if len(buf) > 0 {
+		buf = append(buf, ' ')
+	}
+	buf = append(buf, key...)
+	buf = append(buf, '=')
+	return append(buf, fieldText(value)...)
+}
+
+// Returns key, with characters that would end a logfmt key replaced by '_'.
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			return '_'
+		}
+		return r
+	}, key)
+}
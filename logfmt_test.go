@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"errors"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestLogfmtFormatter(t *testing.T) {
+	r := Record{
+		Time:    time.Date(2009, 1, 23, 1, 23, 23, 0, time.UTC),
+		Level:   LvWarn,
+		Message: "disk almost full",
+		File:    "/src/main.go",
+		Line:    23,
+		Fields:  []Field{F("free", "2%"), F("mount", "/my files"), F("err", errors.New("boom"))},
+	}
+	tcases := []struct {
+		test   string
+		flags  int
+		record Record
+		expect string
+	}{
+		{
+			test:   "All flags",
+			flags:  log.LstdFlags | log.Lshortfile,
+			record: r,
+			expect: `time=2009-01-23T01:23:23Z level=warn caller=main.go:23 msg="disk almost full" free=2% mount="/my files" err=boom` + "\n",
+		},
+		{
+			test:   "No flags",
+			flags:  0,
+			record: r,
+			expect: `level=warn msg="disk almost full" free=2% mount="/my files" err=boom` + "\n",
+		},
+		{
+			test:  "Function and stack",
+			flags: log.Llongfile,
+			record: Record{
+				Level:    LvError,
+				Message:  "failed",
+				File:     "db/conn.go",
+				Line:     12,
+				Function: "db.Query",
+				Stack:    []Frame{{Function: "db.Query", File: "/src/db/conn.go", Line: 12}, {Function: "main.main", File: "/src/main.go", Line: 5}},
+			},
+			expect: `level=error caller=db/conn.go:12 func=db.Query msg=failed stack="db.Query /src/db/conn.go:12\nmain.main /src/main.go:5"` + "\n",
+		},
+		{
+			test:  "Reserved and invalid keys",
+			flags: 0,
+			record: Record{
+				Level:   LvInfo,
+				Message: "hi",
+				Fields:  []Field{F("level", "x"), F("msg", "dup"), F("bad key", "v"), F(`a="b"`, 1), F("", 2)},
+			},
+			expect: `level=info msg=hi fields.level=x fields.msg=dup bad_key=v a__b_=1 _=2` + "\n",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			f := LogfmtFormatter{}
+			if received := string(f.Format(&tcase.record, tcase.flags)); received != tcase.expect {
+				t.Errorf("Expected:\n%q\nReceived:\n%q", tcase.expect, received)
+			}
+		})
+	}
+}
@@ -4,26 +4,60 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"sync"
+	"sync/atomic"
 )
 
 type Logger struct {
-	level LogLevel
-	flags int
-	error *log.Logger
-	info  *log.Logger
-	warn  *log.Logger
-	debug *log.Logger
+	level      LogLevel
+	flags      int
+	stackLevel LogLevel
+	format     Format
+	formatter  Formatter
+	redactor   *redactor
+	scrubber   *Scrubber
+	hooks      []Hook
+	fields     []Field
+	clock      Clock
+	callerSkip int
+	callerMode CallerMode
+	timeFormat TimeFormat
+	lineTimes  *lineTimes
+	error      *log.Logger
+	info       *log.Logger
+	warn       *log.Logger
+	debug      *log.Logger
+
+	hookErrorHandler ErrorHandler
+	errorHandler     ErrorHandler
+	fallback         io.Writer
+
+	writeLock    *sync.Mutex
+	failedWrites *atomic.Uint64
+	fellBack     *atomic.Bool // output was switched to fallback
 }
 
 // Create a new custom Logger
-func New(writer io.Writer) Logger {
-	return Logger{
+func New(writer io.Writer, opts ...Option) Logger {
+	l := Logger{
 		level: defaultLogLevel,
-		error: log.New(writer, "[ERROR] ", defaultLogFlags),
-		info:  log.New(writer, "[INFO ] ", defaultLogFlags),
-		warn:  log.New(writer, "[WARN ] ", defaultLogFlags),
-		debug: log.New(writer, "[DEBUG] ", defaultLogFlags),
+		clock: systemClock{},
+		error: log.New(writer, LvError.prefix(), defaultLogFlags),
+		info:  log.New(writer, LvInfo.prefix(), defaultLogFlags),
+		warn:  log.New(writer, LvWarn.prefix(), defaultLogFlags),
+		debug: log.New(writer, LvDebug.prefix(), defaultLogFlags),
+
+		hookErrorHandler: stderrErrorHandler,
+		errorHandler:     stderrErrorHandler,
+
+		writeLock:    &sync.Mutex{},
+		failedWrites: &atomic.Uint64{},
+		fellBack:     &atomic.Bool{},
 	}
+	for _, opt := range opts {
+		opt(&l)
+	}
+	return l
 }
 
 func (l *Logger) Flags() int {
@@ -34,6 +68,11 @@ func (l *Logger) Level() LogLevel {
 	return l.level
 }
 
+// Enabled reports whether messages logged at level would be written.
+func (l *Logger) Enabled(level LogLevel) bool {
+	return level > LvNone && l.level >= level
+}
+
 func (l *Logger) SetLevel(level LogLevel) {
 	l.level = level
 }
@@ -43,6 +82,10 @@ func (l *Logger) SetOutput(w io.Writer) {
 	l.info.SetOutput(w)
 	l.warn.SetOutput(w)
 	l.debug.SetOutput(w)
+	l.fellBack.Store(false)
+	if l.format != formatCustom {
+		l.formatter = newFormatter(l.format, w)
+	}
 }
 
 func (l *Logger) SetFlags(flags int) {
@@ -53,99 +96,315 @@ func (l *Logger) SetFlags(flags int) {
 	l.debug.SetFlags(flags)
 }
 
-func (l *Logger) Debug(v ...interface{}) {
-	if l.level >= LvDebug {
-		l.debug.Output(2, fmt.Sprint(v...))
+// Captures the goroutine stack for messages logged at level or more severe (ex. LvError).
+// LvNone (default) disables stack traces.
+func (l *Logger) SetStackLevel(level LogLevel) {
+	l.stackLevel = level
+}
+
+// Masks the values of fields with these keys (case-insensitive), before they are formatted.
+// Keys containing a '.' are paths into maps held by a field (ex. "request.headers.authorization"),
+// other keys are matched at any depth (ex. "password").
+// The ErrorFields() of errors are matched as children of the error's field (ex. "error.password").
+//
+// Values implementing Redactable are always replaced.
+func (l *Logger) SetRedactKeys(keys ...string) {
+	l.redactor = newRedactor(keys)
+}
+
+// Scrubs sensitive data from message text (ex. email addresses), before it is formatted.
+// nil (default) disables scrubbing.
+func (l *Logger) SetScrubber(s *Scrubber) {
+	l.scrubber = s
+}
+
+// Clock that records are timestamped with.
+// nil restores the system clock.
+func (l *Logger) SetClock(c Clock) {
+	if c == nil {
+		c = systemClock{}
 	}
+	l.clock = c
 }
 
-func (l *Logger) Info(v ...interface{}) {
-	if l.level >= LvInfo {
-		l.info.Output(2, fmt.Sprint(v...))
+// Skips n additional frames when determining the caller of a message,
+// for wrappers around Logger (see also Helper).
+func (l *Logger) SetCallerSkip(n int) {
+	l.callerSkip = n
+}
+
+// Determines how the caller of a message is recorded (ex. relative to the module root, with it's function).
+// Only used when flags include log.Llongfile or log.Lshortfile.
+func (l *Logger) SetCallerMode(mode CallerMode) {
+	l.callerMode = mode
+}
+
+// Format of the timestamp written by all builtin formatters (ex. TimeRFC3339Nano, TimeDelta).
+// TimeFlags (default) uses the log.Ldate/log.Ltime/log.Lmicroseconds flags.
+// TimeElapsed/TimeDelta are measured from the first line logged after the format is set.
+func (l *Logger) SetTimeFormat(format TimeFormat) {
+	l.timeFormat = format
+	l.lineTimes = nil
+	if format == TimeElapsed || format == TimeDelta {
+		l.lineTimes = &lineTimes{}
 	}
 }
 
-func (l *Logger) Warn(v ...interface{}) {
-	if l.level >= LvWarn {
-		l.warn.Output(2, fmt.Sprint(v...))
+// Adds static fields, written on every line before the message's own fields (ex. ServiceFields("checkout")).
+func (l *Logger) AddFields(fields ...Field) {
+	l.fields = append(l.fields[:len(l.fields):len(l.fields)], fields...)
+}
+
+// Adds a Hook, fired for each message written at one of it's levels.
+func (l *Logger) AddHook(hook Hook) {
+	l.hooks = append(l.hooks[:len(l.hooks):len(l.hooks)], hook)
+}
+
+// Handles errors returned by hooks (as a *HookError).
+// By default, they are written to STDERR.
+func (l *Logger) SetHookErrorHandler(handler ErrorHandler) {
+	l.hookErrorHandler = handler
+}
+
+// Handles errors writing to the output (as a *WriteError).
+// By default, they are written to STDERR.
+func (l *Logger) SetErrorHandler(handler ErrorHandler) {
+	l.errorHandler = handler
+}
+
+// Output that is switched to when writing to the output fails (ex. os.Stderr).
+// The failed line is re-written to it, and it is used until SetOutput() is called.
+func (l *Logger) SetFallbackOutput(w io.Writer) {
+	l.fallback = w
+}
+
+// Number of lines that failed to be written.
+func (l *Logger) FailedWrites() uint64 {
+	return l.failedWrites.Load()
+}
+
+// Selects a builtin Formatter, for all loglevels.
+// Terminal detection for FormatConsole/FormatAuto is repeated when the output changes.
+func (l *Logger) SetFormat(format Format) {
+	l.format = format
+	l.formatter = newFormatter(format, l.error.Writer())
+}
+
+// Formatter used to render each line.
+// When nil, lines are written like the log package, with a '[LEVEL] ' prefix (FormatText).
+func (l *Logger) SetFormatter(f Formatter) {
+	l.format = formatCustom
+	l.formatter = f
+}
+
+// Returns the log.Logger that holds the output/flags for a loglevel.
+// Custom levels share the output/flags of the builtin levels.
+func (l *Logger) loggerFor(level LogLevel) *log.Logger {
+	switch level {
+	case LvError:
+		return l.error
+	case LvWarn:
+		return l.warn
+	case LvInfo:
+		return l.info
+	case LvDebug:
+		return l.debug
 	}
+	return l.error
 }
 
-func (l *Logger) Error(v ...interface{}) {
-	if l.level >= LvError {
-		l.error.Output(2, fmt.Sprint(v...))
+// Print message at any loglevel, including custom levels (see RegisterLevel).
+func (l *Logger) Log(level LogLevel, v ...interface{}) {
+	l.print(2, level, v)
+}
+
+// Printf message at any loglevel, including custom levels (see RegisterLevel).
+func (l *Logger) Logf(level LogLevel, format string, v ...interface{}) {
+	l.printf(2, level, format, v)
+}
+
+// Print the message returned by fn, only calling it if level is Enabled.
+func (l *Logger) LogFunc(level LogLevel, fn func() string) {
+	if l.Enabled(level) {
+		l.output(2, &Record{Level: level, Message: fn()})
 	}
 }
 
+func (l *Logger) Debug(v ...interface{}) {
+	l.print(2, LvDebug, v)
+}
+
+func (l *Logger) Info(v ...interface{}) {
+	l.print(2, LvInfo, v)
+}
+
+func (l *Logger) Warn(v ...interface{}) {
+	l.print(2, LvWarn, v)
+}
+
+func (l *Logger) Error(v ...interface{}) {
+	l.print(2, LvError, v)
+}
+
 func (l *Logger) Debugf(format string, v ...interface{}) {
-	if l.level >= LvDebug {
-		l.debug.Output(2, fmt.Sprintf(format, v...))
-	}
+	l.printf(2, LvDebug, format, v)
 }
 
 func (l *Logger) Infof(format string, v ...interface{}) {
-	if l.level >= LvInfo {
-		l.info.Output(2, fmt.Sprintf(format, v...))
-	}
+	l.printf(2, LvInfo, format, v)
 }
 
 func (l *Logger) Warnf(format string, v ...interface{}) {
-	if l.level >= LvWarn {
-		l.warn.Output(2, fmt.Sprintf(format, v...))
-	}
+	l.printf(2, LvWarn, format, v)
 }
 
 func (l *Logger) Errorf(format string, v ...interface{}) {
-	if l.level >= LvError {
-		l.error.Output(2, fmt.Sprintf(format, v...))
-	}
+	l.printf(2, LvError, format, v)
 }
 
 // Following methods omit caller's call-stack when logging
-func (l *Logger) callerDebug(v ...interface{}) {
-	if l.level >= LvDebug {
-		l.debug.Output(3, fmt.Sprint(v...))
+func (l *Logger) callerLog(level LogLevel, v ...interface{}) {
+	l.print(3, level, v)
+}
+
+func (l *Logger) callerLogf(level LogLevel, format string, v ...interface{}) {
+	l.printf(3, level, format, v)
+}
+
+func (l *Logger) callerLogFunc(level LogLevel, fn func() string) {
+	if l.Enabled(level) {
+		l.output(3, &Record{Level: level, Message: fn()})
 	}
 }
 
+func (l *Logger) callerDebug(v ...interface{}) {
+	l.print(3, LvDebug, v)
+}
+
 func (l *Logger) callerInfo(v ...interface{}) {
-	if l.level >= LvInfo {
-		l.info.Output(3, fmt.Sprint(v...))
-	}
+	l.print(3, LvInfo, v)
 }
 
 func (l *Logger) callerWarn(v ...interface{}) {
-	if l.level >= LvWarn {
-		l.warn.Output(3, fmt.Sprint(v...))
-	}
+	l.print(3, LvWarn, v)
 }
 
 func (l *Logger) callerError(v ...interface{}) {
-	if l.level >= LvError {
-		l.error.Output(3, fmt.Sprint(v...))
-	}
+	l.print(3, LvError, v)
 }
 
 func (l *Logger) callerDebugf(format string, v ...interface{}) {
-	if l.level >= LvDebug {
-		l.debug.Output(3, fmt.Sprintf(format, v...))
-	}
+	l.printf(3, LvDebug, format, v)
 }
 
 func (l *Logger) callerInfof(format string, v ...interface{}) {
-	if l.level >= LvInfo {
-		l.info.Output(3, fmt.Sprintf(format, v...))
-	}
+	l.printf(3, LvInfo, format, v)
 }
 
 func (l *Logger) callerWarnf(format string, v ...interface{}) {
-	if l.level >= LvWarn {
-		l.warn.Output(3, fmt.Sprintf(format, v...))
-	}
+	l.printf(3, LvWarn, format, v)
 }
 
 func (l *Logger) callerErrorf(format string, v ...interface{}) {
-	if l.level >= LvError {
-		l.error.Output(3, fmt.Sprintf(format, v...))
+	l.printf(3, LvError, format, v)
+}
+
+// calldepth is the number of frames between print's caller and the log-caller
+// (ex. 2 when called from Debug).
+func (l *Logger) print(calldepth int, level LogLevel, v []interface{}) {
+	if l.Enabled(level) {
+		args, fields := splitFields(resolveLazy(v))
+		l.output(calldepth+1, &Record{Level: level, Message: fmt.Sprint(redactArgs(args)...), Fields: fields})
+	}
+}
+
+func (l *Logger) printf(calldepth int, level LogLevel, format string, v []interface{}) {
+	if l.Enabled(level) {
+		args, fields := splitFields(resolveLazy(v))
+		l.output(calldepth+1, &Record{Level: level, Message: fmt.Sprintf(format, redactArgs(args)...), Fields: fields})
+	}
+}
+
+func (l *Logger) output(calldepth int, r *Record) {
+	lg := l.loggerFor(r.Level)
+	flags := lg.Flags()
+	r.Time = l.clock.Now()
+	r.timeFormat = l.timeFormat
+	if l.lineTimes != nil {
+		r.elapsed = l.lineTimes.since(r.Time, l.timeFormat)
+	}
+	if flags&(log.Lshortfile|log.Llongfile) != 0 {
+		frame, ok := callerFrame(calldepth + l.callerSkip)
+		l.callerMode.setCaller(r, frame)
+		if !ok {
+			r.File = "???"
+		}
+	}
+	if l.stackLevel > LvNone && r.Level <= l.stackLevel {
+		r.Stack = captureStack(calldepth + l.callerSkip)
+	}
+	if len(l.fields) > 0 {
+		r.Fields = append(l.fields[:len(l.fields):len(l.fields)], r.Fields...)
+	}
+	if len(r.Fields) > 0 {
+		r.Fields = l.redactor.redactFields(r.Fields)
+	}
+	if l.scrubber != nil {
+		r.Message = l.scrubber.Scrub(r.Message)
+	}
+	if len(l.hooks) > 0 {
+		l.fireHooks(r)
+		if len(r.Fields) > 0 {
+			r.Fields = l.redactor.redactFields(r.Fields) // fields added by hooks
+		}
+	}
+
+	err := l.write(lg, r, flags)
+	if err == nil {
+		return
+	}
+	l.writeFailed(err)
+	if l.fallback == nil || l.fellBack.Load() {
+		return
+	}
+	l.switchToFallback()
+	if err := l.write(l.loggerFor(r.Level), r, flags); err != nil {
+		l.writeFailed(err)
+	}
+}
+
+func (l *Logger) write(lg *log.Logger, r *Record, flags int) error {
+	line := formatRecord(l.formatter, r, flags)
+
+	l.writeLock.Lock()
+	defer l.writeLock.Unlock()
+	_, err := lg.Writer().Write(line)
+	return err
+}
+
+func (l *Logger) writeFailed(err error) {
+	l.failedWrites.Add(1)
+	if l.errorHandler != nil {
+		l.errorHandler(&WriteError{Err: err})
+	}
+}
+
+// Writes all levels to the fallback output, keeping the current formatter.
+func (l *Logger) switchToFallback() {
+	l.fellBack.Store(true)
+	l.error.SetOutput(l.fallback)
+	l.info.SetOutput(l.fallback)
+	l.warn.SetOutput(l.fallback)
+	l.debug.SetOutput(l.fallback)
+}
+
+func (l *Logger) fireHooks(r *Record) {
+	for _, hook := range l.hooks {
+		if !hookFires(hook, r.Level) {
+			continue
+		}
+		if err := hook.Fire(r); err != nil && l.hookErrorHandler != nil {
+			l.hookErrorHandler(&HookError{Hook: hook, Err: err})
+		}
 	}
 }
@@ -2,8 +2,10 @@ package logger
 
 import (
 	"log"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestInterfaceMember(t *testing.T) {
@@ -251,3 +253,277 @@ func TestLogger(t *testing.T) {
 		}
 	})
 }
+
+func TestLoggerLog(t *testing.T) {
+	lvNotice := LogLevel(25)
+	lvSecurity := LogLevel(5)
+	if err := RegisterLevel(lvNotice, "NOTICE", ColorCyan); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterLevel(lvSecurity, "SECURITY", ColorMagenta); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		unregisterLevel(lvNotice)
+		unregisterLevel(lvSecurity)
+	})
+
+	tcases := []struct {
+		test  string
+		level LogLevel
+		logs  string
+	}{
+		{
+			test:  "LvError output",
+			level: LvError,
+			logs: leadingWhitespace.ReplaceAllString(
+				`[SECURITY] security
+				 [ERROR] error: foo
+				`,
+				"",
+			),
+		},
+		{
+			test:  "LvWarn output",
+			level: LvWarn,
+			logs: leadingWhitespace.ReplaceAllString(
+				`[SECURITY] security
+				 [ERROR] error: foo
+				 [WARN ] warn
+				`,
+				"",
+			),
+		},
+		{
+			test:  "LvInfo output",
+			level: LvInfo,
+			logs: leadingWhitespace.ReplaceAllString(
+				`[SECURITY] security
+				 [ERROR] error: foo
+				 [WARN ] warn
+				 [NOTICE] notice: foo
+				 [INFO ] info
+				`,
+				"",
+			),
+		},
+	}
+
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			writer := strings.Builder{}
+			logger_ := New(&writer)
+			logger_.SetFlags(0)
+			logger_.SetLevel(tcase.level)
+
+			logger_.Log(lvSecurity, "security")
+			logger_.Logf(LvError, "error: %s", "foo")
+			logger_.Log(LvWarn, "warn")
+			logger_.Logf(lvNotice, "notice: %s", "foo")
+			logger_.Log(LvInfo, "info")
+			logger_.Log(LvNone, "none")
+			if writer.String() != tcase.logs {
+				t.Errorf("Log Messages do not match.\nExpected:\n'%s'\nReceived:\n'%s'", tcase.logs, writer.String())
+			}
+		})
+	}
+}
+
+func TestLoggerEnabled(t *testing.T) {
+	writer := strings.Builder{}
+	logger_ := New(&writer)
+	logger_.SetLevel(LvWarn)
+
+	tcases := []struct {
+		level   LogLevel
+		enabled bool
+	}{
+		{level: LvNone, enabled: false},
+		{level: LvError, enabled: true},
+		{level: LvWarn, enabled: true},
+		{level: LvInfo, enabled: false},
+		{level: LvDebug, enabled: false},
+	}
+	for _, tcase := range tcases {
+		if logger_.Enabled(tcase.level) != tcase.enabled {
+			t.Errorf("Enabled(%s) expected '%t'", tcase.level, tcase.enabled)
+		}
+	}
+}
+
+func TestLoggerLazy(t *testing.T) {
+	writer := strings.Builder{}
+	logger_ := New(&writer)
+	logger_.SetFlags(0)
+	logger_.SetLevel(LvInfo)
+
+	calls := 0
+	dump := func() interface{} {
+		calls++
+		return "dump"
+	}
+	msg := func() string {
+		calls++
+		return "msg"
+	}
+
+	logger_.Debugf("state: %v", Lazy(dump))
+	logger_.Debug(Lazy(dump))
+	logger_.LogFunc(LvDebug, msg)
+	if calls != 0 {
+		t.Errorf("Expected filtered messages not to be evaluated. Evaluated %d times", calls)
+	}
+
+	logger_.Infof("state: %v", Lazy(dump))
+	logger_.LogFunc(LvInfo, msg)
+	expects := "[INFO ] state: dump\n[INFO ] msg\n"
+	if writer.String() != expects {
+		t.Errorf("Log Messages do not match.\nExpected:\n'%s'\nReceived:\n'%s'", expects, writer.String())
+	}
+}
+
+func TestLoggerFields(t *testing.T) {
+	writer := strings.Builder{}
+	logger_ := New(&writer)
+	logger_.SetFlags(0)
+	logger_.SetLevel(LvInfo)
+
+	logger_.Info("user logged in", F("user", "alice"))
+	logger_.Infof("request %s", "/", F("status", 200))
+	expects := "[INFO ] user logged in user=alice\n[INFO ] request / status=200\n"
+	if writer.String() != expects {
+		t.Errorf("Log Messages do not match.\nExpected:\n'%s'\nReceived:\n'%s'", expects, writer.String())
+	}
+}
+
+func TestLoggerSetFormatter(t *testing.T) {
+	writer := strings.Builder{}
+	logger_ := New(&writer)
+	logger_.SetFlags(log.Lshortfile)
+	logger_.SetLevel(LvInfo)
+	logger_.SetFormatter(&ConsoleFormatter{})
+
+	logger_.Info("info", F("key", "val"))
+	rx := regexp.MustCompile(`^INFO  logger_test.go:[0-9]+: info key=val\n$`)
+	if !rx.MatchString(writer.String()) {
+		t.Errorf("Unexpected output from formatter. Received: '%s'", writer.String())
+	}
+}
+
+func TestLoggerSetFormat(t *testing.T) {
+	t.Setenv(FormatEnv, "")
+	writer := strings.Builder{}
+	logger_ := New(&writer, WithFormat(FormatAuto))
+	logger_.SetFlags(0)
+
+	logger_.Error("error", F("key", "val"))
+	expects := `{"level":"ERROR","msg":"error","key":"val"}` + "\n"
+	if writer.String() != expects {
+		t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", expects, writer.String())
+	}
+
+	t.Run("Format is kept when output changes", func(t *testing.T) {
+		newWriter := strings.Builder{}
+		logger_.SetOutput(&newWriter)
+		logger_.Warn("warn")
+		expects := `{"level":"WARN","msg":"warn"}` + "\n"
+		if newWriter.String() != expects {
+			t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", expects, newWriter.String())
+		}
+	})
+
+	t.Run("Custom formatter is kept when output changes", func(t *testing.T) {
+		newWriter := strings.Builder{}
+		logger_.SetFormatter(&ConsoleFormatter{})
+		logger_.SetOutput(&newWriter)
+		logger_.Warn("warn")
+		expects := "WARN  warn\n"
+		if newWriter.String() != expects {
+			t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", expects, newWriter.String())
+		}
+	})
+}
+
+func TestLoggerSetStackLevel(t *testing.T) {
+	writer := strings.Builder{}
+	logger_ := New(&writer)
+	logger_.SetFlags(0)
+	logger_.SetLevel(LvDebug)
+	logger_.SetStackLevel(LvError)
+
+	logger_.Warn("warn")
+	logger_.Error("error")
+	lines := strings.Split(strings.TrimSuffix(writer.String(), "\n"), "\n")
+	if lines[0] != "[WARN ] warn" || lines[1] != "[ERROR] error" {
+		t.Fatalf("Expected stack only for error. Received:\n%s", writer.String())
+	}
+	if len(lines) < 3 {
+		t.Fatalf("Expected stack after error. Received:\n%s", writer.String())
+	}
+	// the log-caller (this test) belongs to this package, so the first frame is the one above it
+	if !strings.HasPrefix(lines[2], "\ttesting.tRunner ") {
+		t.Errorf("Expected stack to start above log-caller. Received: %s", lines[2])
+	}
+	for _, line := range lines[2:] {
+		if strings.Contains(line, "(*Logger)") || strings.Contains(line, "\truntime.") {
+			t.Errorf("Expected logger and runtime frames to be omitted. Received: %s", line)
+		}
+	}
+}
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestLoggerSetClock(t *testing.T) {
+	at := time.Date(2009, 1, 23, 1, 23, 23, 0, time.Local)
+	var buf strings.Builder
+	lg := New(&buf)
+	lg.SetFlags(log.LstdFlags)
+	lg.SetClock(fixedClock(at))
+	lg.Warn("disk almost full")
+	if expect := "[WARN ] 2009/01/23 01:23:23 disk almost full\n"; buf.String() != expect {
+		t.Errorf("Expected %q, Received %q", expect, buf.String())
+	}
+
+	lg.SetClock(nil)
+	if _, ok := lg.clock.(systemClock); !ok {
+		t.Errorf("Expected nil to restore the system clock, Received %T", lg.clock)
+	}
+}
+
+func TestLoggerAddFields(t *testing.T) {
+	tcases := []struct {
+		test      string
+		formatter Formatter
+		expect    string
+	}{
+		{test: "Text", expect: "[WARN ] disk almost full service=checkout pid=42 free=2%\n"},
+		{test: "JSON", formatter: &JSONFormatter{}, expect: `{"level":"WARN","msg":"disk almost full","service":"checkout","pid":42,"free":"2%"}` + "\n"},
+		{test: "Logfmt", formatter: &LogfmtFormatter{}, expect: `level=warn msg="disk almost full" service=checkout pid=42 free=2%` + "\n"},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			var buf strings.Builder
+			lg := New(&buf, WithFields(F("service", "checkout")))
+			lg.AddFields(F("pid", 42))
+			lg.SetFlags(0)
+			lg.SetFormatter(tcase.formatter)
+			lg.Warn("disk almost full", F("free", "2%"))
+			if buf.String() != tcase.expect {
+				t.Errorf("Expected %q, Received %q", tcase.expect, buf.String())
+			}
+		})
+	}
+
+	t.Run("Copies do not share fields", func(t *testing.T) {
+		lg := New(&strings.Builder{}, WithFields(F("service", "checkout")))
+		cp := lg
+		cp.AddFields(F("pid", 42))
+		if len(lg.fields) != 1 || len(cp.fields) != 2 {
+			t.Errorf("Expected 1 and 2 fields, Received %v and %v", lg.fields, cp.fields)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package logtest
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a logger.Clock that only moves when told to,
+// so the timestamps of formatted lines are deterministic.
+//
+// FakeClock is threadsafe.
+type FakeClock struct {
+	now  time.Time
+	step time.Duration
+	lock sync.Mutex
+}
+
+// Creates a FakeClock set to t.
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{now: t}
+}
+
+// Current time of the clock, then advances it by the step (see SetStep).
+func (c *FakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.now
+	c.now = c.now.Add(c.step)
+	return now
+}
+
+// Moves the clock forwards by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Sets the clock to t.
+func (c *FakeClock) Set(t time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = t
+}
+
+// Advances the clock by d each time it is read, so each record has a distinct time.
+// 0 (default) disables stepping.
+func (c *FakeClock) SetStep(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.step = d
+}
@@ -0,0 +1,54 @@
+package logtest
+
+import (
+	"bytes"
+	"log"
+	"testing"
+	"time"
+
+	logger "github.com/willjp/go-logger"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2009, 1, 23, 1, 23, 23, 0, time.UTC)
+
+	t.Run("Advance and Set", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		clock.Advance(time.Second)
+		if expect := start.Add(time.Second); !clock.Now().Equal(expect) {
+			t.Errorf("Expected %s, Received %s", expect, clock.Now())
+		}
+		clock.Set(start)
+		if !clock.Now().Equal(start) {
+			t.Errorf("Expected %s, Received %s", start, clock.Now())
+		}
+	})
+
+	t.Run("Step", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		clock.SetStep(time.Millisecond)
+		clock.Now()
+		if expect := start.Add(time.Millisecond); !clock.Now().Equal(expect) {
+			t.Errorf("Expected %s, Received %s", expect, clock.Now())
+		}
+	})
+
+	t.Run("Logger lines are deterministic", func(t *testing.T) {
+		var buf bytes.Buffer
+		lg := logger.New(&buf, logger.WithClock(NewFakeClock(start)))
+		lg.SetFlags(log.LstdFlags | log.Lmicroseconds | log.LUTC)
+		lg.Warn("disk almost full")
+		if expect := "[WARN ] 2009/01/23 01:23:23.000000 disk almost full\n"; buf.String() != expect {
+			t.Errorf("Expected %q, Received %q", expect, buf.String())
+		}
+	})
+
+	t.Run("StubLogger records are deterministic", func(t *testing.T) {
+		stub := logger.NewStubLogger()
+		stub.SetClock(NewFakeClock(start))
+		stub.Warn("disk almost full")
+		if received := stub.Records()[0].Time; !received.Equal(start) {
+			t.Errorf("Expected %s, Received %s", start, received)
+		}
+	})
+}
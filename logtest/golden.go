@@ -0,0 +1,123 @@
+package logtest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	logger "github.com/willjp/go-logger"
+)
+
+// Namespaced, so it does not conflict with an -update flag defined by the test package.
+var update = flag.Bool("logtest.update", false, "rewrite logtest golden files in testdata/")
+
+var (
+	rfc3339Rx  = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+	dateRx     = regexp.MustCompile(`\d{4}/\d{2}/\d{2}`)
+	timeRx     = regexp.MustCompile(`\d{2}:\d{2}:\d{2}(\.\d+)?`)
+	longfileRx = regexp.MustCompile(`(?:[A-Za-z]:)?(?:[^\s:"'=]*[/\\])+([^\s:"'=/\\]+\.go)`)
+	lineRx     = regexp.MustCompile(`(\.go):\d+`)
+)
+
+// Normalize replaces the parts of log output that change between runs, or when code is edited.
+// Timestamps and line numbers are replaced by placeholders, and file paths are reduced to their basename.
+//
+//	Ex.
+//	    [INFO ] 2009/01/23 01:23:23 /src/main.go:23: msg  ->  [INFO ] YYYY/MM/DD hh:mm:ss main.go:N: msg
+func Normalize(output string) string {
+	output = rfc3339Rx.ReplaceAllString(output, "<TIME>")
+	output = dateRx.ReplaceAllString(output, "YYYY/MM/DD")
+	output = timeRx.ReplaceAllString(output, "hh:mm:ss")
+	output = longfileRx.ReplaceAllString(output, "$1")
+	return lineRx.ReplaceAllString(output, "$1:N")
+}
+
+// AssertGolden compares the messages recorded by stub to testdata/<name>.golden,
+// after they are normalized (see Normalize).
+// Run tests with -logtest.update to rewrite the golden file.
+func AssertGolden(t testing.TB, name string, stub *logger.StubLogger) bool {
+	t.Helper()
+	builder := strings.Builder{}
+	for _, record := range stub.Records() {
+		builder.WriteString(record.Describe())
+		builder.WriteByte('\n')
+	}
+	return assertGolden(t, name, Normalize(builder.String()))
+}
+
+// AssertGoldenOutput compares output captured from a Logger (ex. a bytes.Buffer) to testdata/<name>.golden,
+// after it is normalized (see Normalize).
+// Run tests with -logtest.update to rewrite the golden file.
+func AssertGoldenOutput(t testing.TB, name string, output string) bool {
+	t.Helper()
+	return assertGolden(t, name, Normalize(output))
+}
+
+func assertGolden(t testing.TB, name string, received string) bool {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Unable to create golden file directory: %s", err)
+		}
+		if err := os.WriteFile(path, []byte(received), 0644); err != nil {
+			t.Fatalf("Unable to write golden file: %s", err)
+		}
+		return true
+	}
+
+	expected, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Golden file %s does not exist, run tests with -logtest.update to create it", path)
+		return false
+	}
+	if err != nil {
+		t.Fatalf("Unable to read golden file: %s", err)
+	}
+	if string(expected) == received {
+		return true
+	}
+	t.Errorf("Logs do not match golden file %s (run tests with -logtest.update to rewrite it)\n%s", path, diffLines(string(expected), received))
+	return false
+}
+
+// Renders the lines that differ between expected and received.
+//
+//	Ex.
+//	    3: - [WARN ] disk almost full
+//	    3: + [WARN ] disk full
+func diffLines(expected string, received string) string {
+	expectedLines := strings.Split(expected, "\n")
+	receivedLines := strings.Split(received, "\n")
+	count := len(expectedLines)
+	if len(receivedLines) > count {
+		count = len(receivedLines)
+	}
+
+	builder := strings.Builder{}
+	for i := 0; i < count; i++ {
+		var exp, rcv string
+		if i < len(expectedLines) {
+			exp = expectedLines[i]
+		}
+		if i < len(receivedLines) {
+			rcv = receivedLines[i]
+		}
+		if exp == rcv {
+			continue
+		}
+		if i < len(expectedLines) {
+			fmt.Fprintf(&builder, "%d: - %s\n", i+1, exp)
+		}
+		if i < len(receivedLines) {
+			fmt.Fprintf(&builder, "%d: + %s\n", i+1, rcv)
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,118 @@
+package logtest
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"strings"
+	"testing"
+
+	logger "github.com/willjp/go-logger"
+)
+
+func TestNormalize(t *testing.T) {
+	tcases := []struct {
+		test   string
+		output string
+		expect string
+	}{
+		{
+			test:   "Stdlib date and time",
+			output: "[INFO ] 2009/01/23 01:23:23.123456 main.go:23: msg",
+			expect: "[INFO ] YYYY/MM/DD hh:mm:ss main.go:N: msg",
+		},
+		{
+			test:   "RFC3339",
+			output: `{"time":"2009-01-23T01:23:23.123456789+02:00","msg":"hi"}`,
+			expect: `{"time":"<TIME>","msg":"hi"}`,
+		},
+		{
+			test:   "RFC3339 UTC",
+			output: "2009-01-23T01:23:23Z INFO msg",
+			expect: "<TIME> INFO msg",
+		},
+		{
+			test:   "Long file",
+			output: "[WARN ] /home/user/src/project/main.go:23: msg",
+			expect: "[WARN ] main.go:N: msg",
+		},
+		{
+			test:   "Windows long file",
+			output: `[WARN ] C:\src\project\main.go:23: msg`,
+			expect: "[WARN ] main.go:N: msg",
+		},
+		{
+			test:   "Stack frame",
+			output: "\tmain.run /src/project/main.go:12",
+			expect: "\tmain.run main.go:N",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			if received := Normalize(tcase.output); received != tcase.expect {
+				t.Errorf("Expected %q, Received %q", tcase.expect, received)
+			}
+		})
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	t.Run("Matches golden file", func(t *testing.T) {
+		stub := logger.NewStubLogger()
+		stub.Info("starting")
+		stub.Warn("disk almost full", logger.F("free", "2%"))
+		stub.Error("disk full")
+		AssertGolden(t, "stub", &stub)
+	})
+
+	t.Run("Mismatch reports differing lines", func(t *testing.T) {
+		if *update {
+			t.Skip("golden files are being updated")
+		}
+		stub := logger.NewStubLogger()
+		stub.Info("starting")
+		stub.Warn("disk almost full", logger.F("free", "3%"))
+		stub.Error("disk full")
+		fake := fakeT{TB: t}
+		if AssertGolden(&fake, "stub", &stub) {
+			t.Error("Expected mismatch")
+		}
+		if len(fake.errors) != 1 || !strings.Contains(fake.errors[0], "2: + #2 WARN  golden_test.go:N: disk almost full free=3%") {
+			t.Errorf("Expected diff of second line. Received:\n%s", strings.Join(fake.errors, "\n"))
+		}
+	})
+
+	t.Run("Missing golden file", func(t *testing.T) {
+		if *update {
+			t.Skip("golden files are being updated")
+		}
+		stub := logger.NewStubLogger()
+		fake := fakeT{TB: t}
+		if AssertGolden(&fake, "missing", &stub) {
+			t.Error("Expected failure")
+		}
+		if len(fake.errors) != 1 || !strings.Contains(fake.errors[0], "-logtest.update") {
+			t.Errorf("Expected hint to run with -logtest.update. Received: %v", fake.errors)
+		}
+	})
+}
+
+func TestAssertGoldenOutput(t *testing.T) {
+	var buf bytes.Buffer
+	lg := logger.New(&buf)
+	lg.SetLevel(logger.LvDebug)
+	lg.SetFlags(log.LstdFlags | log.Lmicroseconds | log.Llongfile)
+	lg.Info("starting")
+	lg.Warn("disk almost full", logger.F("free", "2%"))
+	lg.Error("disk full")
+	AssertGoldenOutput(t, "output", buf.String())
+}
+
+func TestUpdateFlagIsNamespaced(t *testing.T) {
+	if flag.Lookup("update") != nil {
+		t.Error("Expected logtest not to define -update, which test packages commonly define themselves")
+	}
+	if flag.Lookup("logtest.update") == nil {
+		t.Error("Expected -logtest.update to be defined")
+	}
+}
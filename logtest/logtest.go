@@ -0,0 +1,147 @@
+// Assertions for messages recorded by a logger.StubLogger.
+//
+//	Ex.
+//	    stub := logger.NewStubLogger()
+//	    // code you are testing
+//	    logtest.AssertLogged(t, &stub, logger.LvInfo, "user logged in")
+//	    logtest.AssertNotLogged(t, &stub, logger.LvError, regexp.MustCompile(`timeout`))
+//
+// Patterns are either a string (matches a substring), or a *regexp.Regexp.
+// Patterns are matched against the message and it's fields (ex. "disk full free=2%").
+// On failure, all recorded messages are printed.
+package logtest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	logger "github.com/willjp/go-logger"
+)
+
+// Entry is a message expected by AssertSequence.
+type Entry struct {
+	Level   logger.LogLevel
+	Pattern interface{} // string or *regexp.Regexp
+}
+
+// AssertLogged fails the test unless a message matching pattern was logged at level.
+func AssertLogged(t testing.TB, stub *logger.StubLogger, level logger.LogLevel, pattern interface{}) bool {
+	t.Helper()
+	records := stub.Records()
+	if indexOf(t, records, 0, level, pattern) >= 0 {
+		return true
+	}
+	t.Errorf("Expected a %s message matching %s\n%s", level, describe(pattern), dump(records))
+	return false
+}
+
+// AssertNotLogged fails the test if a message matching pattern was logged at level.
+func AssertNotLogged(t testing.TB, stub *logger.StubLogger, level logger.LogLevel, pattern interface{}) bool {
+	t.Helper()
+	records := stub.Records()
+	i := indexOf(t, records, 0, level, pattern)
+	if i < 0 {
+		return true
+	}
+	t.Errorf("Expected no %s message matching %s, found #%d\n%s", level, describe(pattern), records[i].Seq, dump(records))
+	return false
+}
+
+// AssertCount fails the test unless exactly n messages were logged at level,
+// including messages discarded by SetCapacity (see StubLogger.Total).
+func AssertCount(t testing.TB, stub *logger.StubLogger, level logger.LogLevel, n int) bool {
+	t.Helper()
+	count := stub.Total(level)
+	if count == n {
+		return true
+	}
+	t.Errorf("Expected %d %s messages, found %d\n%s", n, level, count, dump(stub.Records()))
+	return false
+}
+
+// AssertSequence fails the test unless messages matching entries were logged in this order.
+// Other messages may be logged before, between or after them.
+func AssertSequence(t testing.TB, stub *logger.StubLogger, entries ...Entry) bool {
+	t.Helper()
+	records := stub.Records()
+	next := 0
+	for i, entry := range entries {
+		found := indexOf(t, records, next, entry.Level, entry.Pattern)
+		if found >= 0 {
+			next = found + 1
+			continue
+		}
+
+		builder := strings.Builder{}
+		builder.WriteString("Expected messages in order:\n")
+		for j, entry := range entries {
+			marker := "  "
+			switch {
+			case j < i:
+				marker = "ok"
+			case j == i:
+				marker = "!!"
+			}
+			fmt.Fprintf(&builder, "  %s %-5s %s\n", marker, entry.Level, describe(entry.Pattern))
+		}
+		if next > 0 {
+			fmt.Fprintf(&builder, "%s message matching %s not found after #%d\n", entry.Level, describe(entry.Pattern), records[next-1].Seq)
+		} else {
+			fmt.Fprintf(&builder, "%s message matching %s not found\n", entry.Level, describe(entry.Pattern))
+		}
+		t.Errorf("%s%s", builder.String(), dump(records))
+		return false
+	}
+	return true
+}
+
+// Returns the index of the first record from start that matches level/pattern, or -1.
+func indexOf(t testing.TB, records []logger.StubRecord, start int, level logger.LogLevel, pattern interface{}) int {
+	t.Helper()
+	match := matcher(t, pattern)
+	for i := start; i < len(records); i++ {
+		if records[i].Level == level && match(records[i].String()) {
+			return i
+		}
+	}
+	return -1
+}
+
+func matcher(t testing.TB, pattern interface{}) func(string) bool {
+	t.Helper()
+	switch pattern := pattern.(type) {
+	case string:
+		return func(text string) bool { return strings.Contains(text, pattern) }
+	case *regexp.Regexp:
+		return pattern.MatchString
+	}
+	t.Fatalf("Unsupported pattern type %T, expected string or *regexp.Regexp", pattern)
+	return nil
+}
+
+func describe(pattern interface{}) string {
+	if rx, ok := pattern.(*regexp.Regexp); ok {
+		return "/" + rx.String() + "/"
+	}
+	return fmt.Sprintf("%q", pattern)
+}
+
+// Renders all records, one per line.
+//
+//	Ex.
+//	    Recorded messages:
+//	      #1 WARN  main.go:23: disk almost full free=2%
+func dump(records []logger.StubRecord) string {
+	if len(records) == 0 {
+		return "No messages were recorded"
+	}
+	builder := strings.Builder{}
+	builder.WriteString("Recorded messages:")
+	for _, record := range records {
+		builder.WriteString("\n  ")
+		builder.WriteString(record.Describe())
+	}
+	return builder.String()
+}
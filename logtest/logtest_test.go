@@ -0,0 +1,127 @@
+package logtest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	logger "github.com/willjp/go-logger"
+)
+
+// fakeT records failures, instead of failing the test
+type fakeT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func newStub() *logger.StubLogger {
+	stub := logger.NewStubLogger()
+	stub.Info("starting")
+	stub.Warn("disk almost full", logger.F("free", "2%"))
+	stub.Error("disk full")
+	return &stub
+}
+
+func TestAssertLogged(t *testing.T) {
+	tcases := []struct {
+		test    string
+		level   logger.LogLevel
+		pattern interface{}
+		pass    bool
+	}{
+		{test: "Substring", level: logger.LvWarn, pattern: "almost", pass: true},
+		{test: "Substring of field", level: logger.LvWarn, pattern: "free=2%", pass: true},
+		{test: "Regexp", level: logger.LvError, pattern: regexp.MustCompile(`^disk \w+$`), pass: true},
+		{test: "Wrong level", level: logger.LvInfo, pattern: "disk", pass: false},
+		{test: "No match", level: logger.LvError, pattern: "timeout", pass: false},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			fake := fakeT{TB: t}
+			pass := AssertLogged(&fake, newStub(), tcase.level, tcase.pattern)
+			if pass != tcase.pass || (len(fake.errors) == 0) != tcase.pass {
+				t.Errorf("Expected pass=%t, Received pass=%t errors=%v", tcase.pass, pass, fake.errors)
+			}
+		})
+	}
+
+	t.Run("Failure lists recorded messages", func(t *testing.T) {
+		fake := fakeT{TB: t}
+		AssertLogged(&fake, newStub(), logger.LvError, "timeout")
+		rx := regexp.MustCompile(`#3 ERROR logtest_test.go:[0-9]+: disk full`)
+		if len(fake.errors) != 1 || !rx.MatchString(fake.errors[0]) {
+			t.Errorf("Expected recorded messages in failure. Received:\n%s", strings.Join(fake.errors, "\n"))
+		}
+	})
+}
+
+func TestAssertNotLogged(t *testing.T) {
+	fake := fakeT{TB: t}
+	if !AssertNotLogged(&fake, newStub(), logger.LvError, "timeout") {
+		t.Errorf("Expected pass. Received: %v", fake.errors)
+	}
+	if AssertNotLogged(&fake, newStub(), logger.LvError, "disk") {
+		t.Error("Expected failure")
+	}
+	if len(fake.errors) != 1 || !strings.Contains(fake.errors[0], "found #3") {
+		t.Errorf("Expected failure to reference matching message. Received: %v", fake.errors)
+	}
+}
+
+func TestAssertCount(t *testing.T) {
+	fake := fakeT{TB: t}
+	if !AssertCount(&fake, newStub(), logger.LvWarn, 1) {
+		t.Errorf("Expected pass. Received: %v", fake.errors)
+	}
+	if AssertCount(&fake, newStub(), logger.LvDebug, 1) {
+		t.Error("Expected failure")
+	}
+	if len(fake.errors) != 1 || !strings.Contains(fake.errors[0], "Expected 1 DEBUG messages, found 0") {
+		t.Errorf("Unexpected failure message. Received: %v", fake.errors)
+	}
+	t.Run("Counts messages discarded by capacity", func(t *testing.T) {
+		fake := fakeT{TB: t}
+		stub := logger.NewStubLogger()
+		stub.SetCapacity(2)
+		for i := 0; i < 5; i++ {
+			stub.Info("tick")
+		}
+		if !AssertCount(&fake, &stub, logger.LvInfo, 5) {
+			t.Errorf("Expected pass. Received: %v", fake.errors)
+		}
+	})
+}
+
+func TestAssertSequence(t *testing.T) {
+	t.Run("In order", func(t *testing.T) {
+		fake := fakeT{TB: t}
+		pass := AssertSequence(&fake, newStub(),
+			Entry{Level: logger.LvInfo, Pattern: "starting"},
+			Entry{Level: logger.LvError, Pattern: "full"},
+		)
+		if !pass {
+			t.Errorf("Expected pass. Received: %v", fake.errors)
+		}
+	})
+
+	t.Run("Out of order", func(t *testing.T) {
+		fake := fakeT{TB: t}
+		pass := AssertSequence(&fake, newStub(),
+			Entry{Level: logger.LvError, Pattern: "disk full"},
+			Entry{Level: logger.LvWarn, Pattern: "almost"},
+		)
+		if pass {
+			t.Fatal("Expected failure")
+		}
+		if !strings.Contains(fake.errors[0], "WARN message matching \"almost\" not found after #3") {
+			t.Errorf("Unexpected failure message. Received:\n%s", fake.errors[0])
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package logger
+
+// Option configures a Logger created by New.
+type Option func(l *Logger)
+
+// Output Format of the Logger (ex. FormatAuto).
+func WithFormat(format Format) Option {
+	return func(l *Logger) {
+		l.SetFormat(format)
+	}
+}
+
+// Skips n additional frames when determining the caller of a message,
+// for wrappers around Logger (see also Helper).
+func WithCallerSkip(n int) Option {
+	return func(l *Logger) {
+		l.SetCallerSkip(n)
+	}
+}
+
+// Determines how the caller of a message is recorded (see CallerMode).
+func WithCallerMode(mode CallerMode) Option {
+	return func(l *Logger) {
+		l.SetCallerMode(mode)
+	}
+}
+
+// Format of the timestamp written by all builtin formatters (see TimeFormat).
+func WithTimeFormat(format TimeFormat) Option {
+	return func(l *Logger) {
+		l.SetTimeFormat(format)
+	}
+}
+
+// Static fields, written on every line (see Logger.AddFields).
+func WithFields(fields ...Field) Option {
+	return func(l *Logger) {
+		l.AddFields(fields...)
+	}
+}
+
+// Clock that records are timestamped with (ex. a fake clock in tests).
+func WithClock(c Clock) Option {
+	return func(l *Logger) {
+		l.SetClock(c)
+	}
+}
@@ -0,0 +1,30 @@
+package logger
+
+import "time"
+
+// Record is a single message that is being logged.
+type Record struct {
+	Time     time.Time
+	Level    LogLevel
+	Message  string
+	File     string // set when flags include log.Lshortfile or log.Llongfile
+	Line     int
+	Function string // set when the Logger's caller mode includes CallerModeFunc
+	Fields   []Field
+	Stack    []Frame // set when Level is at/above the Logger's stack level
+
+	timeFormat TimeFormat    // the Logger's TimeFormat
+	elapsed    time.Duration // set for TimeElapsed and TimeDelta
+}
+
+// Renders the Record's message, fields and stack as plain text.
+//
+//	Ex.
+//	    disk almost full free=2%
+func (r *Record) String() string {
+	if len(r.Fields) == 0 && len(r.Stack) == 0 {
+		return r.Message
+	}
+	buf := appendFields([]byte(r.Message), r.Fields)
+	return string(appendStack(buf, r.Stack))
+}
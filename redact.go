@@ -0,0 +1,155 @@
+package logger
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Replaces the value of redacted fields
+const Redacted = "[REDACTED]"
+
+// Redactable is implemented by values that contain sensitive data.
+// Redact returns the value that should be logged in it's place.
+//
+// Redactable values are replaced whether they are a log argument, a field, or nested within a field's map.
+type Redactable interface {
+	Redact() interface{}
+}
+
+// redactor masks the values of configured keys
+type redactor struct {
+	names map[string]bool // keys matched at any depth (ex. "password")
+	paths map[string]bool // keys matched from the root field (ex. "request.headers.authorization")
+}
+
+// Keys are case-insensitive.
+// Keys containing a '.' are paths into the maps held by a field, others match a key at any depth.
+func newRedactor(keys []string) *redactor {
+	r := redactor{names: map[string]bool{}, paths: map[string]bool{}}
+	for _, key := range keys {
+		key = strings.ToLower(key)
+		if strings.Contains(key, ".") {
+			r.paths[key] = true
+		} else {
+			r.names[key] = true
+		}
+	}
+	return &r
+}
+
+func (this *redactor) hasKeys() bool {
+	return this != nil && (len(this.names) > 0 || len(this.paths) > 0)
+}
+
+func (this *redactor) matches(key string, path string) bool {
+	if !this.hasKeys() {
+		return false
+	}
+	return this.names[strings.ToLower(key)] || this.paths[strings.ToLower(path)]
+}
+
+// Returns fields, with sensitive values replaced.
+// A nil redactor only replaces Redactable values.
+func (this *redactor) redactFields(fields []Field) []Field {
+	redacted := make([]Field, len(fields))
+	for i, field := range fields {
+		value, _ := this.redactValue(field.Key, field.Key, field.Value)
+		redacted[i] = Field{Key: field.Key, Value: value}
+	}
+	return redacted
+}
+
+// Returns value with sensitive data replaced, and whether anything was replaced.
+// Maps with string keys are always copied, errors only when their ErrorFields() are redacted.
+func (this *redactor) redactValue(key string, path string, value interface{}) (interface{}, bool) {
+	if this.matches(key, path) {
+		return Redacted, true
+	}
+	if redactable, ok := value.(Redactable); ok {
+		return redactable.Redact(), true
+	}
+	if err, ok := value.(error); ok {
+		return this.redactError(path, err)
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return value, false
+	}
+	copied := make(map[string]interface{}, rv.Len())
+	changed := false
+	iter := rv.MapRange()
+	for iter.Next() {
+		childKey := iter.Key().String()
+		childValue, childChanged := this.redactValue(childKey, path+"."+childKey, iter.Value().Interface())
+		copied[childKey] = childValue
+		changed = changed || childChanged
+	}
+	return copied, changed
+}
+
+// Redacts the ErrorFields() of err and the errors it wraps, matching them as children of path.
+// Returns a *redactedError if any were replaced.
+func (this *redactor) redactError(path string, err error) (interface{}, bool) {
+	node := newErrorNode(err)
+	if !this.redactNode(path, &node) {
+		return err, false
+	}
+	return &redactedError{node: node}, true
+}
+
+func (this *redactor) redactNode(path string, node *errorNode) bool {
+	changed := false
+	if len(node.fields) > 0 {
+		fields := make([]Field, len(node.fields))
+		for i, field := range node.fields {
+			value, fieldChanged := this.redactValue(field.Key, path+"."+field.Key, field.Value)
+			fields[i] = Field{Key: field.Key, Value: value}
+			changed = changed || fieldChanged
+		}
+		if changed {
+			node.fields = fields
+			node.Fields = make(map[string]interface{}, len(fields))
+			for _, field := range fields {
+				node.Fields[field.Key] = field.Value
+			}
+		}
+	}
+	for i := range node.Causes {
+		if this.redactNode(path, &node.Causes[i]) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// redactedError is an error whose ErrorFields() were redacted.
+// It is rendered from it's errorNode, instead of the original error.
+type redactedError struct {
+	node errorNode
+}
+
+func (e *redactedError) Error() string {
+	return e.node.Message
+}
+
+// Returns v, with Redactable arguments replaced.
+// v is only copied if it contains a Redactable argument.
+func redactArgs(v []interface{}) []interface{} {
+	var redacted []interface{}
+	for i, arg := range v {
+		redactable, ok := arg.(Redactable)
+		if !ok {
+			continue
+		}
+		if redacted == nil {
+			redacted = make([]interface{}, len(v))
+			copy(redacted, v)
+		}
+		redacted[i] = redactable.Redact()
+	}
+	if redacted == nil {
+		return v
+	}
+	return redacted
+}
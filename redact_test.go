@@ -0,0 +1,142 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type apiToken string
+
+func (t apiToken) Redact() interface{} {
+	return string(t[:3]) + "..."
+}
+
+func TestRedactFields(t *testing.T) {
+	headers := map[string]string{"Authorization": "Bearer abc", "Accept": "*/*"}
+	fields := []Field{
+		F("user", "alice"),
+		F("Password", "hunter2"),
+		F("request", map[string]interface{}{
+			"headers": headers,
+			"body":    map[string]string{"password": "hunter2"},
+		}),
+		F("token", apiToken("abcdef")),
+	}
+
+	t.Run("Redacts keys and paths", func(t *testing.T) {
+		r := newRedactor([]string{"password", "request.headers.authorization"})
+		expects := []Field{
+			F("user", "alice"),
+			F("Password", Redacted),
+			F("request", map[string]interface{}{
+				"headers": map[string]interface{}{"Authorization": Redacted, "Accept": "*/*"},
+				"body":    map[string]interface{}{"password": Redacted},
+			}),
+			F("token", "abc..."),
+		}
+		received := r.redactFields(fields)
+		if !reflect.DeepEqual(received, expects) {
+			t.Errorf("Expected:\n%v\nReceived:\n%v", expects, received)
+		}
+		if headers["Authorization"] != "Bearer abc" {
+			t.Error("Expected logged map not to be modified")
+		}
+	})
+
+	t.Run("Paths only match from root", func(t *testing.T) {
+		r := newRedactor([]string{"headers.authorization"})
+		received := r.redactFields([]Field{F("request", map[string]interface{}{"headers": headers})})
+		nested := received[0].Value.(map[string]interface{})["headers"].(map[string]interface{})
+		if nested["Authorization"] != "Bearer abc" {
+			t.Errorf("Expected nested path not to be redacted. Received: %v", nested)
+		}
+	})
+
+	t.Run("Nil redactor only replaces Redactable", func(t *testing.T) {
+		var r *redactor
+		received := r.redactFields(fields)
+		if received[1].Value != "hunter2" || received[3].Value != "abc..." {
+			t.Errorf("Unexpected redaction: %v", received)
+		}
+	})
+}
+
+func TestLoggerRedact(t *testing.T) {
+	writer := strings.Builder{}
+	logger_ := New(&writer)
+	logger_.SetFlags(0)
+	logger_.SetRedactKeys("password")
+
+	logger_.Errorf("login %s failed", apiToken("abcdef"), F("user", "alice"), F("password", "hunter2"))
+	expects := "[ERROR] login abc... failed user=alice password=[REDACTED]\n"
+	if writer.String() != expects {
+		t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", expects, writer.String())
+	}
+}
+
+// error carrying sensitive context
+type loginError struct {
+	password string
+}
+
+func (e *loginError) Error() string { return "login failed" }
+func (e *loginError) ErrorFields() []Field {
+	return []Field{F("user", "alice"), F("password", e.password)}
+}
+
+func TestLoggerRedactErrorFields(t *testing.T) {
+	err := fmt.Errorf("handle: %w", &loginError{password: "hunter2"})
+	tcases := []struct {
+		test      string
+		formatter Formatter
+		expect    string
+	}{
+		{
+			test:   "Text",
+			expect: `[ERROR] failed error="handle: login failed" error.chain="*fmt.wrapError -> *logger.loginError{user=alice password=[REDACTED]}"` + "\n",
+		},
+		{
+			test:      "JSON",
+			formatter: &JSONFormatter{},
+			expect: `{"level":"ERROR","msg":"failed","error":{"message":"handle: login failed","type":"*fmt.wrapError","causes":[` +
+				`{"message":"login failed","type":"*logger.loginError","fields":{"password":"[REDACTED]","user":"alice"}}]}}` + "\n",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			writer := strings.Builder{}
+			logger_ := New(&writer)
+			logger_.SetFlags(0)
+			logger_.SetFormatter(tcase.formatter)
+			logger_.SetRedactKeys("password")
+			logger_.Error("failed", Err(err))
+			if writer.String() != tcase.expect {
+				t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", tcase.expect, writer.String())
+			}
+		})
+	}
+}
+
+func TestLoggerRedactableWithoutKeys(t *testing.T) {
+	writer := strings.Builder{}
+	logger_ := New(&writer)
+	logger_.SetFlags(0)
+	logger_.SetFormatter(&JSONFormatter{})
+	logger_.Error("failed",
+		F("m", map[string]interface{}{"tok": apiToken("abcdef")}),
+		Err(&tokenError{token: apiToken("abcdef")}),
+	)
+	expects := `{"level":"ERROR","msg":"failed","m":{"tok":"abc..."},"error":{"message":"invalid token","type":"*logger.tokenError","fields":{"token":"abc..."}}}` + "\n"
+	if writer.String() != expects {
+		t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", expects, writer.String())
+	}
+}
+
+type tokenError struct {
+	token apiToken
+}
+
+func (e *tokenError) Error() string        { return "invalid token" }
+func (e *tokenError) ErrorFields() []Field { return []Field{F("token", e.token)} }
@@ -0,0 +1,163 @@
+package logger
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Names of builtin Scrubber detectors
+const (
+	DetectBearer = "bearer" // Authorization bearer tokens
+	DetectJWT    = "jwt"    // JSON web tokens
+	DetectEmail  = "email"  // email addresses
+	DetectPAN    = "pan"    // payment card numbers, that pass a Luhn check
+	DetectIPv4   = "ipv4"   // IPv4 addresses
+)
+
+var builtinDetectors = map[string]detector{
+	DetectBearer: {
+		name:    DetectBearer,
+		pattern: regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`),
+	},
+	DetectJWT: {
+		name:    DetectJWT,
+		pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	},
+	DetectEmail: {
+		name:    DetectEmail,
+		pattern: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+	},
+	DetectPAN: {
+		name:    DetectPAN,
+		pattern: regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+		locate:  locatePAN,
+	},
+	DetectIPv4: {
+		name:    DetectIPv4,
+		pattern: regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`),
+	},
+}
+
+// Order builtin detectors are applied in, so that longer matches are replaced first.
+var builtinDetectorOrder = []string{DetectBearer, DetectJWT, DetectEmail, DetectPAN, DetectIPv4}
+
+type detector struct {
+	name    string
+	pattern *regexp.Regexp
+	locate  func(match string) (start int, end int, ok bool) // optional, the sensitive part of a match, rejecting false-positives
+}
+
+// Scrubber replaces sensitive data found in message text (ex. email addresses).
+type Scrubber struct {
+	// Returns the text that replaces a match.
+	// By default, matches are replaced with the detector's name (ex. "[EMAIL]").
+	Replace func(name string, match string) string
+
+	detectors []detector
+}
+
+// Creates a Scrubber using the named builtin detectors (ex. DetectEmail).
+// When no names are provided, all builtin detectors are used.
+// Unknown names are ignored.
+func NewScrubber(names ...string) *Scrubber {
+	if len(names) == 0 {
+		names = builtinDetectorOrder
+	}
+	s := Scrubber{}
+	for _, name := range builtinDetectorOrder {
+		for _, requested := range names {
+			if requested == name {
+				s.detectors = append(s.detectors, builtinDetectors[name])
+			}
+		}
+	}
+	return &s
+}
+
+// Adds a user-defined detector, applied after all previously added detectors.
+func (s *Scrubber) AddPattern(name string, pattern *regexp.Regexp) {
+	s.detectors = append(s.detectors, detector{name: name, pattern: pattern})
+}
+
+// Returns text, with sensitive data replaced.
+func (s *Scrubber) Scrub(text string) string {
+	for _, d := range s.detectors {
+		d := d
+		text = d.pattern.ReplaceAllStringFunc(text, func(match string) string {
+			if d.locate == nil {
+				return s.replace(d.name, match)
+			}
+			start, end, ok := d.locate(match)
+			if !ok {
+				return match
+			}
+			return match[:start] + s.replace(d.name, match[start:end]) + match[end:]
+		})
+	}
+	return text
+}
+
+func (s *Scrubber) replace(name string, match string) string {
+	if s.Replace != nil {
+		return s.Replace(name, match)
+	}
+	return "[" + strings.ToUpper(name) + "]"
+}
+
+// Locates the leftmost, longest groups of digits within match that pass a Luhn check.
+// The pattern is greedy, so digits following a card number (ex. a CVV or expiry) may be part of the match.
+// Groups are separated by spaces or dashes, and are never split.
+func locatePAN(match string) (int, int, bool) {
+	var starts, ends []int
+	for i := 0; i < len(match); i++ {
+		if !isDigit(match[i]) {
+			continue
+		}
+		if i == 0 || !isDigit(match[i-1]) {
+			starts = append(starts, i)
+		}
+		if i == len(match)-1 || !isDigit(match[i+1]) {
+			ends = append(ends, i+1)
+		}
+	}
+	for first := range starts {
+		for last := len(ends) - 1; last >= first; last-- {
+			if isLuhn(match[starts[first]:ends[last]]) {
+				return starts[first], ends[last], true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// Reports whether the digits in number pass the Luhn checksum.
+// Spaces and dashes are ignored.
+func isLuhn(number string) bool {
+	sum := 0
+	digits := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		if c < '0' || c > '9' {
+			return false
+		}
+		n := int(c - '0')
+		if double {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+		digits++
+		double = !double
+	}
+	return digits >= 13 && digits <= 19 && sum%10 == 0
+}
@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestScrubber(t *testing.T) {
+	tcases := []struct {
+		test   string
+		text   string
+		expect string
+	}{
+		{test: "Email", text: "sent to alice.b+x@example.co.uk today", expect: "sent to [EMAIL] today"},
+		{test: "PAN", text: "card 4111 1111 1111 1111 declined", expect: "card [PAN] declined"},
+		{test: "PAN with dashes", text: "card 4111-1111-1111-1111", expect: "card [PAN]"},
+		{test: "PAN followed by CVV", text: "card 4111 1111 1111 1111 123 ok", expect: "card [PAN] 123 ok"},
+		{test: "PAN followed by expiry", text: "card 4111111111111111 12 25", expect: "card [PAN] 12 25"},
+		{test: "PAN preceded by digits", text: "ref 12 4111111111111111", expect: "ref 12 [PAN]"},
+		{test: "Number failing Luhn", text: "order 4111111111111112", expect: "order 4111111111111112"},
+		{test: "JWT", text: "token eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig-_1 rejected", expect: "token [JWT] rejected"},
+		{test: "Bearer", text: "Authorization: Bearer abc.def/123= ok", expect: "Authorization: [BEARER] ok"},
+		{test: "IPv4", text: "from 192.168.0.1:80", expect: "from [IPV4]:80"},
+		{test: "Not an IPv4", text: "version 1.2.3", expect: "version 1.2.3"},
+		{test: "Clean", text: "nothing to see here", expect: "nothing to see here"},
+	}
+	s := NewScrubber()
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			received := s.Scrub(tcase.text)
+			if received != tcase.expect {
+				t.Errorf("Expected '%s', Received '%s'", tcase.expect, received)
+			}
+		})
+	}
+
+	t.Run("Selected detectors", func(t *testing.T) {
+		s := NewScrubber(DetectEmail)
+		received := s.Scrub("alice@example.com from 10.0.0.1")
+		if received != "[EMAIL] from 10.0.0.1" {
+			t.Errorf("Expected only emails to be scrubbed. Received '%s'", received)
+		}
+	})
+
+	t.Run("User-defined pattern and replacement", func(t *testing.T) {
+		s := NewScrubber(DetectPAN)
+		s.AddPattern("ssn", regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`))
+		s.Replace = func(name string, match string) string {
+			return strings.Repeat("*", len(match)-4) + match[len(match)-4:]
+		}
+		received := s.Scrub("ssn 123-45-6789 card 4111111111111111")
+		if received != "ssn *******6789 card ************1111" {
+			t.Errorf("Unexpected scrubbed text '%s'", received)
+		}
+	})
+}
+
+func TestIsLuhn(t *testing.T) {
+	tcases := []struct {
+		number string
+		valid  bool
+	}{
+		{number: "4111111111111111", valid: true},
+		{number: "5500 0000 0000 0004", valid: true},
+		{number: "4111111111111112", valid: false},
+		{number: "0000000000", valid: false},
+	}
+	for _, tcase := range tcases {
+		if isLuhn(tcase.number) != tcase.valid {
+			t.Errorf("isLuhn(%s) expected '%t'", tcase.number, tcase.valid)
+		}
+	}
+}
+
+func TestLoggerScrubber(t *testing.T) {
+	writer := strings.Builder{}
+	logger_ := New(&writer)
+	logger_.SetFlags(0)
+	logger_.SetScrubber(NewScrubber())
+
+	logger_.Warnf("password reset for %s", "alice@example.com")
+	expects := "[WARN ] password reset for [EMAIL]\n"
+	if writer.String() != expects {
+		t.Errorf("Expected:\n'%s'\nReceived:\n'%s'", expects, writer.String())
+	}
+}
+
+func BenchmarkScrubber(b *testing.B) {
+	s := NewScrubber()
+	lines := []struct {
+		name string
+		text string
+	}{
+		{name: "Clean", text: "GET /api/v1/users/42 completed in 12ms status=200"},
+		{name: "Sensitive", text: "payment by alice@example.com from 10.0.0.1 with card 4111 1111 1111 1111 failed"},
+	}
+	for _, line := range lines {
+		b.Run(line.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				s.Scrub(line.text)
+			}
+		})
+	}
+}
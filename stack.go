@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Frame is a single function-call in a captured goroutine stack.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// Renders Frame as 'pkg.Function /path/file.go:line'
+func (f Frame) String() string {
+	return f.Function + " " + f.File + ":" + strconv.Itoa(f.Line)
+}
+
+const maxStackDepth = 64
+
+// Import path of this package (ex. "github.com/willjp/go-logger")
+var pkgPath = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	return funcPackage(runtime.FuncForPC(pc).Name())
+}()
+
+// Captures the stack of the current goroutine, starting skip frames above the caller of captureStack.
+// Frames from the runtime and this package are omitted.
+func captureStack(skip int) []Frame {
+	stack := []Frame{}
+	for _, frame := range callerFrames(skip + 1) {
+		if !isFilteredFrame(frame) {
+			stack = append(stack, Frame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		}
+	}
+	return stack
+}
+
+// Returns the unfiltered stack of the current goroutine, starting skip frames above the caller of callerFrames.
+func callerFrames(skip int) []runtime.Frame {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	stack := []runtime.Frame{}
+	for n > 0 {
+		frame, more := frames.Next()
+		stack = append(stack, frame)
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+func isFilteredFrame(frame runtime.Frame) bool {
+	if strings.HasPrefix(frame.Function, "runtime.") {
+		return true
+	}
+	return strings.HasPrefix(frame.Function, pkgPath+".")
+}
+
+// Appends stack, one indented frame per line.
+func appendStack(buf []byte, stack []Frame) []byte {
+	for _, frame := range stack {
+		buf = append(buf, "\n\t"...)
+		buf = append(buf, frame.String()...)
+	}
+	return buf
+}
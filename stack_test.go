@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCallerFrames(t *testing.T) {
+	frames := callerFrames(0)
+	if len(frames) < 2 {
+		t.Fatal("Expected stack frames")
+	}
+	if frames[0].Function != pkgPath+".TestCallerFrames" || frames[1].Function != "testing.tRunner" {
+		t.Errorf("Expected stack to start at caller. Received: %s, %s", frames[0].Function, frames[1].Function)
+	}
+}
+
+func TestCaptureStack(t *testing.T) {
+	stack := captureStack(0)
+	if len(stack) == 0 {
+		t.Fatal("Expected stack frames")
+	}
+	// this test's own frame belongs to this package, so the first frame is the one above it
+	if stack[0].Function != "testing.tRunner" {
+		t.Errorf("Expected stack to start above caller. Received: %s", stack[0])
+	}
+	for _, frame := range stack {
+		if strings.HasPrefix(frame.Function, "runtime.") {
+			t.Errorf("Expected runtime frames to be omitted. Received: %s", frame)
+		}
+	}
+}
+
+func TestIsFilteredFrame(t *testing.T) {
+	tcases := []struct {
+		frame    runtime.Frame
+		filtered bool
+	}{
+		{frame: runtime.Frame{Function: "runtime.goexit", File: "/go/src/runtime/asm_amd64.s"}, filtered: true},
+		{frame: runtime.Frame{Function: pkgPath + ".(*Logger).output", File: "/src/logger.go"}, filtered: true},
+		{frame: runtime.Frame{Function: pkgPath + ".TestFoo", File: "/src/logger_test.go"}, filtered: true},
+		{frame: runtime.Frame{Function: "main.main", File: "/src/main.go"}, filtered: false},
+	}
+	for _, tcase := range tcases {
+		if isFilteredFrame(tcase.frame) != tcase.filtered {
+			t.Errorf("isFilteredFrame(%s) expected '%t'", tcase.frame.Function, tcase.filtered)
+		}
+	}
+}
+
+func TestPkgPath(t *testing.T) {
+	if pkgPath != "github.com/willjp/go-logger" {
+		t.Errorf("Expected 'github.com/willjp/go-logger', Received '%s'", pkgPath)
+	}
+}
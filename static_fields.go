@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"os"
+	"runtime/debug"
+)
+
+// Keys of the static fields returned by ServiceFields.
+const (
+	HostKey     = "host"
+	PIDKey      = "pid"
+	ServiceKey  = "service"
+	VersionKey  = "version"
+	RevisionKey = "revision"
+)
+
+// Fields identifying a service, to attach to every line (see Logger.AddFields).
+// The host and pid of the process are included, along with the BuildInfoFields.
+//
+//	Ex.
+//	    service=checkout host=web-1 pid=4242 version=v1.2.3 revision=8573f74
+func ServiceFields(service string) []Field {
+	fields := []Field{F(ServiceKey, service)}
+	fields = append(fields, ProcessFields()...)
+	return append(fields, BuildInfoFields()...)
+}
+
+// The host and pid of the process.
+func ProcessFields() []Field {
+	fields := []Field{}
+	if host, err := os.Hostname(); err == nil {
+		fields = append(fields, F(HostKey, host))
+	}
+	return append(fields, F(PIDKey, os.Getpid()))
+}
+
+// The version of the main module, and the VCS revision it was built from (from runtime/debug.ReadBuildInfo).
+// Values that are unavailable (ex. 'go run', builds without VCS stamping) are omitted.
+func BuildInfoFields() []Field {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return []Field{}
+	}
+	return buildInfoFields(info)
+}
+
+func buildInfoFields(info *debug.BuildInfo) []Field {
+	fields := []Field{}
+	if version := info.Main.Version; version != "" && version != "(devel)" {
+		fields = append(fields, F(VersionKey, version))
+	}
+	revision, modified := "", false
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if revision != "" {
+		if modified {
+			revision += "-dirty"
+		}
+		fields = append(fields, F(RevisionKey, revision))
+	}
+	return fields
+}
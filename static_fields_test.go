@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"os"
+	"reflect"
+	"runtime/debug"
+	"testing"
+)
+
+func TestBuildInfoFields(t *testing.T) {
+	tcases := []struct {
+		test   string
+		info   debug.BuildInfo
+		expect []Field
+	}{
+		{
+			test: "Version and revision",
+			info: debug.BuildInfo{
+				Main:     debug.Module{Path: "github.com/user/project", Version: "v1.2.3"},
+				Settings: []debug.BuildSetting{{Key: "vcs.revision", Value: "8573f74"}, {Key: "vcs.modified", Value: "false"}},
+			},
+			expect: []Field{F(VersionKey, "v1.2.3"), F(RevisionKey, "8573f74")},
+		},
+		{
+			test: "Modified revision",
+			info: debug.BuildInfo{
+				Main:     debug.Module{Path: "github.com/user/project", Version: "(devel)"},
+				Settings: []debug.BuildSetting{{Key: "vcs.revision", Value: "8573f74"}, {Key: "vcs.modified", Value: "true"}},
+			},
+			expect: []Field{F(RevisionKey, "8573f74-dirty")},
+		},
+		{
+			test:   "Unavailable",
+			info:   debug.BuildInfo{},
+			expect: []Field{},
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			if received := buildInfoFields(&tcase.info); !reflect.DeepEqual(received, tcase.expect) {
+				t.Errorf("Expected %v, Received %v", tcase.expect, received)
+			}
+		})
+	}
+}
+
+func TestServiceFields(t *testing.T) {
+	fields := ServiceFields("checkout")
+	values := map[string]interface{}{}
+	for _, field := range fields {
+		values[field.Key] = field.Value
+	}
+	if values[ServiceKey] != "checkout" || values[PIDKey] != os.Getpid() {
+		t.Errorf("Expected service and pid fields, Received %v", fields)
+	}
+	if host, err := os.Hostname(); err == nil && values[HostKey] != host {
+		t.Errorf("Expected host %q, Received %v", host, values[HostKey])
+	}
+	if len(fields) < 3 || fields[0].Key != ServiceKey || fields[1].Key != HostKey || fields[2].Key != PIDKey {
+		t.Errorf("Expected fields ordered service, host, pid. Received %v", fields)
+	}
+}
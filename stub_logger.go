@@ -3,6 +3,9 @@ package logger
 import (
 	"fmt"
 	"io"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/willjp/go-logger/internal/spinlock"
 )
@@ -10,21 +13,60 @@ import (
 // StubLogger records the strings that were logged to each log-level.
 // It is designed for testing log messages.
 //
+// Each message is also recorded in a single ordered log (see Records()),
+// which can be used to test the order messages were logged in.
+// Records can also be rendered like Logger would write them (see SetRender()),
+// and written to an output while debugging tests (see SetTee()).
+// Long running tests can bound the number of messages kept for each level (see SetCapacity()).
+//
 // StubLogger is threadsafe.
 type StubLogger struct {
-	level LogLevel
-	flags int
+	level      LogLevel
+	flags      int
+	render     bool
+	formatter  Formatter
+	output     io.Writer
+	tee        TeeMode
+	capacity   int
+	clock      Clock
+	skip       int
+	callerMode CallerMode
 
 	ErrorMsgs []string
 	InfoMsgs  []string
 	WarnMsgs  []string
 	DebugMsgs []string
 
-	optsLock  *spinlock.SpinLock
-	errorLock *spinlock.SpinLock
-	infoLock  *spinlock.SpinLock
-	warnLock  *spinlock.SpinLock
-	debugLock *spinlock.SpinLock
+	// messages logged to custom levels (see RegisterLevel)
+	CustomMsgs map[LogLevel][]string
+
+	records map[LogLevel][]StubRecord
+	totals  map[LogLevel]int
+	seq     uint64
+	strict  *stubStrict
+
+	optsLock    *spinlock.SpinLock
+	errorLock   *spinlock.SpinLock
+	infoLock    *spinlock.SpinLock
+	warnLock    *spinlock.SpinLock
+	debugLock   *spinlock.SpinLock
+	customLock  *spinlock.SpinLock
+	recordsLock *spinlock.SpinLock
+	outputLock  *sync.Mutex
+}
+
+// StubRecord is a message recorded by StubLogger.
+// The Record's message excludes it's fields, and it's caller is always recorded.
+type StubRecord struct {
+	Seq      uint64 // order the message was logged in, starting at 1
+	Rendered string // line written by Logger with the same flags/formatter (see SetRender), otherwise empty
+	Record
+}
+
+// Describes the record for test failures, as '#<seq> LEVEL file.go:line: message'.
+func (r StubRecord) Describe() string {
+	file := r.File[strings.LastIndexByte(r.File, '/')+1:]
+	return fmt.Sprintf("#%d %-5s %s:%d: %s", r.Seq, r.Level, file, r.Line, r.String())
 }
 
 // Creates a StubLogger
@@ -33,17 +75,26 @@ func NewStubLogger() StubLogger {
 	return StubLogger{
 		level: LvDebug,
 		flags: defaultLogFlags,
+		clock: systemClock{},
 
 		ErrorMsgs: []string{},
 		InfoMsgs:  []string{},
 		WarnMsgs:  []string{},
 		DebugMsgs: []string{},
 
-		optsLock:  &spinlock.SpinLock{},
-		errorLock: &spinlock.SpinLock{},
-		infoLock:  &spinlock.SpinLock{},
-		warnLock:  &spinlock.SpinLock{},
-		debugLock: &spinlock.SpinLock{},
+		CustomMsgs: map[LogLevel][]string{},
+
+		records: map[LogLevel][]StubRecord{},
+		totals:  map[LogLevel]int{},
+
+		optsLock:    &spinlock.SpinLock{},
+		errorLock:   &spinlock.SpinLock{},
+		infoLock:    &spinlock.SpinLock{},
+		warnLock:    &spinlock.SpinLock{},
+		debugLock:   &spinlock.SpinLock{},
+		customLock:  &spinlock.SpinLock{},
+		recordsLock: &spinlock.SpinLock{},
+		outputLock:  &sync.Mutex{},
 	}
 }
 
@@ -65,9 +116,20 @@ func (this *StubLogger) SetLevel(level LogLevel) {
 	this.level = level
 }
 
+// Output records are written to, formatted like Logger, when enabled by SetTee.
+// Messages are always recorded.
 func (this *StubLogger) SetOutput(w io.Writer) {
-	// ignored, the purpose of this object is to record calls
-	return
+	this.optsLock.Acquire()
+	defer this.optsLock.Release()
+	this.output = w
+}
+
+// Determines when records are written to the output (see SetOutput).
+// TeeModeNever (default) ignores the output.
+func (this *StubLogger) SetTee(mode TeeMode) {
+	this.optsLock.Acquire()
+	defer this.optsLock.Release()
+	this.tee = mode
 }
 
 func (this *StubLogger) SetFlags(flags int) {
@@ -76,66 +138,396 @@ func (this *StubLogger) SetFlags(flags int) {
 	this.flags = flags
 }
 
-func (this *StubLogger) Debug(v ...interface{}) {
-	if this.Level() >= LvDebug {
-		this.debugLock.Acquire()
-		defer this.debugLock.Release()
-		this.DebugMsgs = append(this.DebugMsgs, fmt.Sprint(v...))
+// Renders each record like Logger would write it, using the StubLogger's flags and formatter,
+// storing the line in StubRecord.Rendered.
+func (this *StubLogger) SetRender(render bool) {
+	this.optsLock.Acquire()
+	defer this.optsLock.Release()
+	this.render = render
+}
+
+// Formatter used to render records (see SetRender).
+// When nil (default), records are rendered like FormatText.
+func (this *StubLogger) SetFormatter(f Formatter) {
+	this.optsLock.Acquire()
+	defer this.optsLock.Release()
+	this.formatter = f
+}
+
+// Clock that records are timestamped with.
+// nil restores the system clock.
+func (this *StubLogger) SetClock(c Clock) {
+	if c == nil {
+		c = systemClock{}
 	}
+	this.optsLock.Acquire()
+	defer this.optsLock.Release()
+	this.clock = c
 }
 
-func (this *StubLogger) Info(v ...interface{}) {
-	if this.Level() >= LvInfo {
-		this.infoLock.Acquire()
-		defer this.infoLock.Release()
-		this.InfoMsgs = append(this.InfoMsgs, fmt.Sprint(v...))
+// Skips n additional frames when determining the caller of a message,
+// for wrappers around the logger (see also Helper).
+func (this *StubLogger) SetCallerSkip(n int) {
+	this.optsLock.Acquire()
+	defer this.optsLock.Release()
+	this.skip = n
+}
+
+// Determines how the caller of a message is recorded (see CallerMode).
+func (this *StubLogger) SetCallerMode(mode CallerMode) {
+	this.optsLock.Acquire()
+	defer this.optsLock.Release()
+	this.callerMode = mode
+}
+
+// Keeps only the last n messages/records logged to each level, discarding older ones.
+// 0 (default) keeps all messages. Totals include discarded messages (see Total).
+func (this *StubLogger) SetCapacity(n int) {
+	this.optsLock.Acquire()
+	this.capacity = n
+	this.optsLock.Release()
+	if n <= 0 {
+		return
+	}
+
+	this.acquireAll()
+	defer this.releaseAll()
+	this.ErrorMsgs = trimMsgs(this.ErrorMsgs, n)
+	this.WarnMsgs = trimMsgs(this.WarnMsgs, n)
+	this.InfoMsgs = trimMsgs(this.InfoMsgs, n)
+	this.DebugMsgs = trimMsgs(this.DebugMsgs, n)
+	for level, msgs := range this.CustomMsgs {
+		this.CustomMsgs[level] = trimMsgs(msgs, n)
+	}
+	for level, records := range this.records {
+		this.records[level] = trimRecords(records, n)
 	}
 }
 
+func (this *StubLogger) Enabled(level LogLevel) bool {
+	return level > LvNone && this.Level() >= level
+}
+
+func (this *StubLogger) Debug(v ...interface{}) {
+	this.print(2, LvDebug, v)
+}
+
+func (this *StubLogger) Info(v ...interface{}) {
+	this.print(2, LvInfo, v)
+}
+
 func (this *StubLogger) Warn(v ...interface{}) {
-	if this.Level() >= LvWarn {
-		this.warnLock.Acquire()
-		defer this.warnLock.Release()
-		this.WarnMsgs = append(this.WarnMsgs, fmt.Sprint(v...))
-	}
+	this.print(2, LvWarn, v)
 }
 
 func (this *StubLogger) Error(v ...interface{}) {
-	if this.Level() >= LvError {
-		this.errorLock.Acquire()
-		defer this.errorLock.Release()
-		this.ErrorMsgs = append(this.ErrorMsgs, fmt.Sprint(v...))
-	}
+	this.print(2, LvError, v)
 }
 
 func (this *StubLogger) Debugf(format string, v ...interface{}) {
-	if this.Level() >= LvDebug {
-		this.debugLock.Acquire()
-		defer this.debugLock.Release()
-		this.DebugMsgs = append(this.DebugMsgs, fmt.Sprintf(format, v...))
-	}
+	this.printf(2, LvDebug, format, v)
 }
 
 func (this *StubLogger) Infof(format string, v ...interface{}) {
-	if this.Level() >= LvInfo {
-		this.infoLock.Acquire()
-		defer this.infoLock.Release()
-		this.InfoMsgs = append(this.InfoMsgs, fmt.Sprintf(format, v...))
-	}
+	this.printf(2, LvInfo, format, v)
 }
 
 func (this *StubLogger) Warnf(format string, v ...interface{}) {
-	if this.Level() >= LvWarn {
-		this.warnLock.Acquire()
-		defer this.warnLock.Release()
-		this.WarnMsgs = append(this.WarnMsgs, fmt.Sprintf(format, v...))
-	}
+	this.printf(2, LvWarn, format, v)
 }
 
 func (this *StubLogger) Errorf(format string, v ...interface{}) {
-	if this.Level() >= LvError {
-		this.errorLock.Acquire()
-		defer this.errorLock.Release()
-		this.ErrorMsgs = append(this.ErrorMsgs, fmt.Sprintf(format, v...))
+	this.printf(2, LvError, format, v)
+}
+
+func (this *StubLogger) Log(level LogLevel, v ...interface{}) {
+	this.print(2, level, v)
+}
+
+func (this *StubLogger) Logf(level LogLevel, format string, v ...interface{}) {
+	this.printf(2, level, format, v)
+}
+
+func (this *StubLogger) LogFunc(level LogLevel, fn func() string) {
+	if this.Enabled(level) {
+		this.record(2, &Record{Level: level, Message: fn()})
+	}
+}
+
+// calldepth is the number of frames between print's caller and the log-caller
+// (ex. 2 when called from Debug).
+func (this *StubLogger) print(calldepth int, level LogLevel, v []interface{}) {
+	if this.Enabled(level) {
+		args, fields := splitFields(resolveLazy(v))
+		this.record(calldepth+1, &Record{Level: level, Message: fmt.Sprint(args...), Fields: fields})
+	}
+}
+
+func (this *StubLogger) printf(calldepth int, level LogLevel, format string, v []interface{}) {
+	if this.Enabled(level) {
+		args, fields := splitFields(resolveLazy(v))
+		this.record(calldepth+1, &Record{Level: level, Message: fmt.Sprintf(format, args...), Fields: fields})
+	}
+}
+
+// Copy of the messages recorded at level.
+// Unlike the exported fields, this is safe to call while other goroutines are logging.
+func (this *StubLogger) Messages(level LogLevel) []string {
+	lock := this.lockFor(level)
+	lock.Acquire()
+	defer lock.Release()
+	return copyMsgs(this.msgsFor(level))
+}
+
+// Copy of the messages recorded at each level.
+func (this *StubLogger) All() map[LogLevel][]string {
+	snapshot := this.Snapshot()
+	return snapshot.All()
+}
+
+// Copy of all messages, in the order they were logged.
+func (this *StubLogger) Records() []StubRecord {
+	this.recordsLock.Acquire()
+	defer this.recordsLock.Release()
+	return mergeRecords(this.records)
+}
+
+// Number of messages logged at level, including messages discarded by SetCapacity.
+func (this *StubLogger) Total(level LogLevel) int {
+	this.recordsLock.Acquire()
+	defer this.recordsLock.Release()
+	return this.totals[level]
+}
+
+// Rendered lines of all records, in the order they were logged (see SetRender).
+// This is the output Logger would have written.
+func (this *StubLogger) Output() string {
+	builder := strings.Builder{}
+	for _, record := range this.Records() {
+		builder.WriteString(record.Rendered)
+	}
+	return builder.String()
+}
+
+// Copy of all recorded messages, taken while no messages are being recorded.
+func (this *StubLogger) Snapshot() StubSnapshot {
+	this.acquireAll()
+	defer this.releaseAll()
+	snapshot := StubSnapshot{
+		ErrorMsgs:  copyMsgs(this.ErrorMsgs),
+		WarnMsgs:   copyMsgs(this.WarnMsgs),
+		InfoMsgs:   copyMsgs(this.InfoMsgs),
+		DebugMsgs:  copyMsgs(this.DebugMsgs),
+		CustomMsgs: make(map[LogLevel][]string, len(this.CustomMsgs)),
+		Records:    mergeRecords(this.records),
+	}
+	for level, msgs := range this.CustomMsgs {
+		snapshot.CustomMsgs[level] = copyMsgs(msgs)
+	}
+	return snapshot
+}
+
+// Discards all recorded messages.
+// Messages already logged are still checked by strict mode (see SetStrict).
+func (this *StubLogger) Reset() {
+	this.acquireAll()
+	defer this.releaseAll()
+	this.ErrorMsgs = []string{}
+	this.WarnMsgs = []string{}
+	this.InfoMsgs = []string{}
+	this.DebugMsgs = []string{}
+	this.CustomMsgs = map[LogLevel][]string{}
+	this.records = map[LogLevel][]StubRecord{}
+	this.totals = map[LogLevel]int{}
+	this.seq = 0
+}
+
+// Appends the message to the array for it's loglevel, and to the ordered records.
+func (this *StubLogger) record(calldepth int, r *Record) {
+	this.optsLock.Acquire()
+	clock, skip, callerMode := this.clock, this.skip, this.callerMode
+	render, formatter, flags, capacity := this.render, this.formatter, this.flags, this.capacity
+	output, tee := this.output, this.output != nil && this.tee.enabled()
+	strict := this.strict
+	this.optsLock.Release()
+	r.Time = clock.Now()
+	frame, ok := callerFrame(calldepth + skip)
+	callerMode.setCaller(r, frame)
+	if !ok {
+		r.File = "???"
+	}
+	var line []byte
+	if render || tee {
+		line = formatRecord(formatter, r, flags)
+	}
+	if tee {
+		this.outputLock.Lock()
+		output.Write(line)
+		this.outputLock.Unlock()
+	}
+	var rendered string
+	if render {
+		rendered = string(line)
+	}
+
+	level := r.Level
+	msg := r.String()
+	lock := this.lockFor(level)
+	lock.Acquire()
+	defer lock.Release()
+	this.recordsLock.Acquire()
+	defer this.recordsLock.Release()
+	keep := -1 // room for the new message, within capacity
+	if capacity > 0 {
+		keep = capacity - 1
+	}
+	this.seq++
+	this.totals[level]++
+	record := StubRecord{Seq: this.seq, Rendered: rendered, Record: *r}
+	this.records[level] = append(trimRecords(this.records[level], keep), record)
+	if strict != nil {
+		strict.check(record)
+	}
+	switch level {
+	case LvError:
+		this.ErrorMsgs = append(trimMsgs(this.ErrorMsgs, keep), msg)
+	case LvWarn:
+		this.WarnMsgs = append(trimMsgs(this.WarnMsgs, keep), msg)
+	case LvInfo:
+		this.InfoMsgs = append(trimMsgs(this.InfoMsgs, keep), msg)
+	case LvDebug:
+		this.DebugMsgs = append(trimMsgs(this.DebugMsgs, keep), msg)
+	default:
+		this.CustomMsgs[level] = append(trimMsgs(this.CustomMsgs[level], keep), msg)
+	}
+}
+
+// Returns the messages recorded for level. Caller must hold lockFor(level).
+func (this *StubLogger) msgsFor(level LogLevel) []string {
+	switch level {
+	case LvError:
+		return this.ErrorMsgs
+	case LvWarn:
+		return this.WarnMsgs
+	case LvInfo:
+		return this.InfoMsgs
+	case LvDebug:
+		return this.DebugMsgs
+	}
+	return this.CustomMsgs[level]
+}
+
+func (this *StubLogger) lockFor(level LogLevel) *spinlock.SpinLock {
+	switch level {
+	case LvError:
+		return this.errorLock
+	case LvWarn:
+		return this.warnLock
+	case LvInfo:
+		return this.infoLock
+	case LvDebug:
+		return this.debugLock
+	}
+	return this.customLock
+}
+
+// Acquires the lock for every level (always in the same order, to avoid deadlocks).
+func (this *StubLogger) acquireAll() {
+	this.errorLock.Acquire()
+	this.warnLock.Acquire()
+	this.infoLock.Acquire()
+	this.debugLock.Acquire()
+	this.customLock.Acquire()
+	this.recordsLock.Acquire()
+}
+
+func (this *StubLogger) releaseAll() {
+	this.recordsLock.Release()
+	this.customLock.Release()
+	this.debugLock.Release()
+	this.infoLock.Release()
+	this.warnLock.Release()
+	this.errorLock.Release()
+}
+
+func copyMsgs(msgs []string) []string {
+	copied := make([]string, len(msgs))
+	copy(copied, msgs)
+	return copied
+}
+
+// Merges the records of each level into a new slice, in the order they were logged.
+func mergeRecords(records map[LogLevel][]StubRecord) []StubRecord {
+	count := 0
+	for _, levelRecords := range records {
+		count += len(levelRecords)
+	}
+	merged := make([]StubRecord, 0, count)
+	for _, levelRecords := range records {
+		merged = append(merged, levelRecords...)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Seq < merged[j].Seq
+	})
+	return merged
+}
+
+// Keeps the last keep messages (all when keep is negative).
+// Appending to the result reuses the array until it is full, then copies only the kept messages,
+// so memory stays bounded.
+func trimMsgs(msgs []string, keep int) []string {
+	if keep < 0 || len(msgs) <= keep {
+		return msgs
+	}
+	return msgs[len(msgs)-keep:]
+}
+
+// Keeps the last keep records (see trimMsgs).
+func trimRecords(records []StubRecord, keep int) []StubRecord {
+	if keep < 0 || len(records) <= keep {
+		return records
+	}
+	return records[len(records)-keep:]
+}
+
+// StubSnapshot is a copy of the messages recorded by a StubLogger at a point in time.
+type StubSnapshot struct {
+	ErrorMsgs  []string
+	WarnMsgs   []string
+	InfoMsgs   []string
+	DebugMsgs  []string
+	CustomMsgs map[LogLevel][]string
+	Records    []StubRecord
+}
+
+// Messages recorded at level.
+func (s *StubSnapshot) Messages(level LogLevel) []string {
+	switch level {
+	case LvError:
+		return s.ErrorMsgs
+	case LvWarn:
+		return s.WarnMsgs
+	case LvInfo:
+		return s.InfoMsgs
+	case LvDebug:
+		return s.DebugMsgs
+	}
+	return s.CustomMsgs[level]
+}
+
+// Messages recorded at each level.
+// Levels without messages are omitted.
+func (s *StubSnapshot) All() map[LogLevel][]string {
+	all := map[LogLevel][]string{}
+	for _, level := range []LogLevel{LvError, LvWarn, LvInfo, LvDebug} {
+		if msgs := s.Messages(level); len(msgs) > 0 {
+			all[level] = msgs
+		}
+	}
+	for level, msgs := range s.CustomMsgs {
+		if len(msgs) > 0 {
+			all[level] = msgs
+		}
 	}
+	return all
 }
@@ -1,7 +1,11 @@
 package logger
 
 import (
+	"bytes"
+	"fmt"
+	"log"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -252,3 +256,287 @@ func TestStubLoggerLevels(t *testing.T) {
 
 	})
 }
+
+func TestStubLoggerLog(t *testing.T) {
+	lvNotice := LogLevel(25)
+	if err := RegisterLevel(lvNotice, "NOTICE", ColorCyan); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { unregisterLevel(lvNotice) })
+
+	logger_ := NewStubLogger()
+	logger_.SetLevel(LvInfo)
+	logger_.Log(LvWarn, "warn")
+	logger_.Logf(lvNotice, "notice: %s", "foo")
+	logger_.Log(LvDebug, "debug")
+
+	if !reflect.DeepEqual(logger_.WarnMsgs, []string{"warn"}) {
+		t.Errorf("Expected builtin levels to be recorded in WarnMsgs. Received: %v", logger_.WarnMsgs)
+	}
+	if !reflect.DeepEqual(logger_.CustomMsgs[lvNotice], []string{"notice: foo"}) {
+		t.Errorf("Expected custom levels to be recorded in CustomMsgs. Received: %v", logger_.CustomMsgs)
+	}
+	if len(logger_.DebugMsgs) != 0 {
+		t.Errorf("Expected debug message to be filtered. Received: %v", logger_.DebugMsgs)
+	}
+}
+
+func TestStubLoggerLazy(t *testing.T) {
+	logger_ := NewStubLogger()
+	logger_.SetLevel(LvInfo)
+	if logger_.Enabled(LvDebug) || !logger_.Enabled(LvInfo) {
+		t.Error("Enabled() does not respect Level()")
+	}
+
+	calls := 0
+	dump := func() interface{} {
+		calls++
+		return "dump"
+	}
+	logger_.Debugf("state: %v", Lazy(dump))
+	logger_.LogFunc(LvDebug, func() string { calls++; return "msg" })
+	if calls != 0 {
+		t.Errorf("Expected filtered messages not to be evaluated. Evaluated %d times", calls)
+	}
+
+	logger_.Infof("state: %v", Lazy(dump))
+	logger_.LogFunc(LvInfo, func() string { return "msg" })
+	if !reflect.DeepEqual(logger_.InfoMsgs, []string{"state: dump", "msg"}) {
+		t.Errorf("Expected lazy values to be recorded. Received: %v", logger_.InfoMsgs)
+	}
+}
+
+func TestStubLoggerAccessors(t *testing.T) {
+	lvNotice := LogLevel(25)
+	if err := RegisterLevel(lvNotice, "NOTICE", ColorCyan); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { unregisterLevel(lvNotice) })
+
+	logger_ := NewStubLogger()
+	logger_.Error("error")
+	logger_.Info("info")
+	logger_.Log(lvNotice, "notice")
+
+	t.Run("Messages", func(t *testing.T) {
+		msgs := logger_.Messages(LvInfo)
+		if !reflect.DeepEqual(msgs, []string{"info"}) {
+			t.Errorf("Expected '[info]', Received '%v'", msgs)
+		}
+		msgs[0] = "modified"
+		if logger_.InfoMsgs[0] != "info" {
+			t.Error("Expected Messages() to return a copy")
+		}
+		if msgs := logger_.Messages(lvNotice); !reflect.DeepEqual(msgs, []string{"notice"}) {
+			t.Errorf("Expected '[notice]', Received '%v'", msgs)
+		}
+	})
+
+	t.Run("All", func(t *testing.T) {
+		expects := map[LogLevel][]string{
+			LvError:  {"error"},
+			LvInfo:   {"info"},
+			lvNotice: {"notice"},
+		}
+		if all := logger_.All(); !reflect.DeepEqual(all, expects) {
+			t.Errorf("Expected '%v', Received '%v'", expects, all)
+		}
+	})
+
+	t.Run("Snapshot", func(t *testing.T) {
+		snapshot := logger_.Snapshot()
+		logger_.Error("after")
+		if !reflect.DeepEqual(snapshot.ErrorMsgs, []string{"error"}) {
+			t.Errorf("Expected snapshot not to change. Received '%v'", snapshot.ErrorMsgs)
+		}
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		logger_.Reset()
+		if all := logger_.All(); len(all) != 0 {
+			t.Errorf("Expected no messages after Reset(). Received '%v'", all)
+		}
+	})
+}
+
+func TestStubLoggerConcurrentAccess(t *testing.T) {
+	logger_ := NewStubLogger()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			logger_.Info("info")
+			logger_.Error("error")
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		logger_.Messages(LvInfo)
+		logger_.Snapshot()
+	}
+	<-done
+	if len(logger_.Messages(LvInfo)) != 1000 || len(logger_.Messages(LvError)) != 1000 {
+		t.Error("Expected all messages to be recorded")
+	}
+}
+
+func TestStubLoggerRecords(t *testing.T) {
+	logger_ := NewStubLogger()
+	logger_.Warn("disk almost full", F("free", "2%"))
+	logger_.Errorf("disk %s", "full")
+	logger_.LogFunc(LvInfo, func() string { return "cleanup" })
+
+	records := logger_.Records()
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, Received %d", len(records))
+	}
+	expects := []struct {
+		seq     uint64
+		level   LogLevel
+		message string
+	}{
+		{seq: 1, level: LvWarn, message: "disk almost full"},
+		{seq: 2, level: LvError, message: "disk full"},
+		{seq: 3, level: LvInfo, message: "cleanup"},
+	}
+	for i, expect := range expects {
+		record := records[i]
+		if record.Seq != expect.seq || record.Level != expect.level || record.Message != expect.message {
+			t.Errorf("Expected (%d, %s, '%s'), Received (%d, %s, '%s')",
+				expect.seq, expect.level, expect.message, record.Seq, record.Level, record.Message)
+		}
+		if !strings.HasSuffix(record.File, "stub_logger_test.go") || record.Line == 0 {
+			t.Errorf("Expected log-caller to be recorded. Received '%s:%d'", record.File, record.Line)
+		}
+		if record.Time.IsZero() {
+			t.Error("Expected time to be recorded")
+		}
+	}
+	if !reflect.DeepEqual(records[0].Fields, []Field{F("free", "2%")}) {
+		t.Errorf("Expected fields to be recorded. Received '%v'", records[0].Fields)
+	}
+	if logger_.WarnMsgs[0] != "disk almost full free=2%" {
+		t.Errorf("Expected per-level messages to include fields. Received '%s'", logger_.WarnMsgs[0])
+	}
+
+	t.Run("Reset", func(t *testing.T) {
+		logger_.Reset()
+		logger_.Info("info")
+		records := logger_.Records()
+		if len(records) != 1 || records[0].Seq != 1 {
+			t.Errorf("Expected Reset() to clear records. Received '%v'", records)
+		}
+	})
+}
+
+func TestStubLoggerRender(t *testing.T) {
+	logWarning := func(l Interface) {
+		l.Warn("disk almost full", F("free", "2%"))
+	}
+	tcases := []struct {
+		test      string
+		flags     int
+		formatter Formatter
+	}{
+		{test: "Text", flags: log.Lshortfile},
+		{test: "Text with message prefix", flags: log.Llongfile | log.Lmsgprefix},
+		{test: "JSON", flags: log.Lshortfile, formatter: &JSONFormatter{}},
+		{test: "Console", flags: log.Lshortfile, formatter: &ConsoleFormatter{}},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			var buf bytes.Buffer
+			lg := New(&buf)
+			lg.SetFlags(tcase.flags)
+			lg.SetFormatter(tcase.formatter)
+			logWarning(&lg)
+
+			stub := NewStubLogger()
+			stub.SetFlags(tcase.flags)
+			stub.SetFormatter(tcase.formatter)
+			stub.SetRender(true)
+			logWarning(&stub)
+
+			if stub.Output() != buf.String() {
+				t.Errorf("Expected %q, Received %q", buf.String(), stub.Output())
+			}
+			records := stub.Records()
+			if records[0].Message != "disk almost full" || records[0].Rendered != buf.String() {
+				t.Errorf("Expected raw message and rendered line. Received %#v", records[0])
+			}
+		})
+	}
+
+	t.Run("Disabled by default", func(t *testing.T) {
+		stub := NewStubLogger()
+		stub.Warn("disk almost full")
+		if records := stub.Records(); records[0].Rendered != "" || stub.Output() != "" {
+			t.Errorf("Expected no rendered lines. Received %#v", records[0])
+		}
+	})
+}
+
+func TestStubLoggerCapacity(t *testing.T) {
+	t.Run("Keeps last messages of each level", func(t *testing.T) {
+		stub := NewStubLogger()
+		stub.SetCapacity(2)
+		for i := 1; i <= 5; i++ {
+			stub.Debugf("debug %d", i)
+		}
+		stub.Error("error 1")
+
+		if expect := []string{"debug 4", "debug 5"}; !reflect.DeepEqual(stub.DebugMsgs, expect) {
+			t.Errorf("Expected %v, Received %v", expect, stub.DebugMsgs)
+		}
+		var received []string
+		for _, record := range stub.Records() {
+			received = append(received, fmt.Sprintf("%d:%s", record.Seq, record.Message))
+		}
+		if expect := []string{"4:debug 4", "5:debug 5", "6:error 1"}; !reflect.DeepEqual(received, expect) {
+			t.Errorf("Expected %v, Received %v", expect, received)
+		}
+		if stub.Total(LvDebug) != 5 || stub.Total(LvError) != 1 {
+			t.Errorf("Expected totals 5 and 1, Received %d and %d", stub.Total(LvDebug), stub.Total(LvError))
+		}
+	})
+
+	t.Run("Memory stays bounded", func(t *testing.T) {
+		stub := NewStubLogger()
+		stub.SetCapacity(10)
+		for i := 0; i < 10000; i++ {
+			stub.Debug("msg")
+		}
+		if len(stub.DebugMsgs) != 10 || cap(stub.DebugMsgs) > 40 {
+			t.Errorf("Expected 10 messages in a bounded array, Received len=%d cap=%d", len(stub.DebugMsgs), cap(stub.DebugMsgs))
+		}
+		if stub.Total(LvDebug) != 10000 {
+			t.Errorf("Expected total of 10000, Received %d", stub.Total(LvDebug))
+		}
+	})
+
+	t.Run("Trims existing messages", func(t *testing.T) {
+		stub := NewStubLogger()
+		stub.Info("a")
+		stub.Info("b")
+		stub.Info("c")
+		stub.SetCapacity(1)
+		if expect := []string{"c"}; !reflect.DeepEqual(stub.Messages(LvInfo), expect) || len(stub.Records()) != 1 {
+			t.Errorf("Expected %v, Received %v", expect, stub.Messages(LvInfo))
+		}
+	})
+
+	t.Run("Reset clears totals", func(t *testing.T) {
+		stub := NewStubLogger()
+		stub.Info("a")
+		stub.Reset()
+		if stub.Total(LvInfo) != 0 {
+			t.Errorf("Expected total of 0, Received %d", stub.Total(LvInfo))
+		}
+	})
+}
+
+func TestStubRecordDescribe(t *testing.T) {
+	r := StubRecord{Seq: 2, Record: Record{Level: LvWarn, Message: "disk almost full", File: "/src/main.go", Line: 23, Fields: []Field{F("free", "2%")}}}
+	if expect := "#2 WARN  main.go:23: disk almost full free=2%"; r.Describe() != expect {
+		t.Errorf("Expected %q, Received %q", expect, r.Describe())
+	}
+}
@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/willjp/go-logger/internal/spinlock"
+)
+
+// stubExpectation is an error/warn message that a strict StubLogger expects.
+type stubExpectation struct {
+	level   LogLevel
+	pattern *regexp.Regexp
+	matched bool
+}
+
+// strict mode settings of a StubLogger.
+// Records are checked as they are logged, so only unexpected records are kept.
+type stubStrict struct {
+	t            TB
+	expectations []stubExpectation
+	allowed      []*regexp.Regexp
+	unexpected   []StubRecord // kept regardless of the StubLogger's capacity
+	lock         *spinlock.SpinLock
+}
+
+// Binds the StubLogger to a test, failing it if unexpected warn/error messages are logged.
+//
+// When the test completes, it fails if a message was logged at LvWarn or a more severe level
+// that was not Expect()ed or Allow()ed, or if an Expect()ed message was not logged.
+// Messages discarded by SetCapacity or Reset are still checked.
+//
+//	Ex.
+//	    stub := logger.NewStubLogger()
+//	    stub.SetStrict(t)
+//	    stub.Expect(logger.LvError, regexp.MustCompile(`connection refused`))
+func (this *StubLogger) SetStrict(t TB) {
+	strict := &stubStrict{t: t, lock: &spinlock.SpinLock{}}
+	for _, record := range this.Records() {
+		strict.check(record)
+	}
+	this.optsLock.Acquire()
+	defer this.optsLock.Release()
+	this.strict = strict
+	t.Cleanup(this.verifyStrict)
+}
+
+// Expects a message matching pattern to be logged at level.
+// Has no effect unless the StubLogger is strict (see SetStrict).
+func (this *StubLogger) Expect(level LogLevel, pattern *regexp.Regexp) {
+	if strict := this.strictMode(); strict != nil {
+		strict.lock.Acquire()
+		defer strict.lock.Release()
+		strict.expectations = append(strict.expectations, stubExpectation{level: level, pattern: pattern})
+	}
+}
+
+// Allows messages matching pattern to be logged at any level, without failing the test.
+// Has no effect unless the StubLogger is strict (see SetStrict).
+func (this *StubLogger) Allow(pattern *regexp.Regexp) {
+	if strict := this.strictMode(); strict != nil {
+		strict.lock.Acquire()
+		defer strict.lock.Release()
+		strict.allowed = append(strict.allowed, pattern)
+	}
+}
+
+func (this *StubLogger) strictMode() *stubStrict {
+	this.optsLock.Acquire()
+	defer this.optsLock.Release()
+	return this.strict
+}
+
+// Reports unexpected messages, and expected messages that were not logged.
+func (this *StubLogger) verifyStrict() {
+	strict := this.strictMode()
+	if strict == nil {
+		return
+	}
+
+	strict.lock.Acquire()
+	builder := strings.Builder{}
+	for _, record := range strict.unexpected {
+		if !strict.match(record) { // Expect()ed or Allow()ed after it was logged
+			fmt.Fprintf(&builder, "\n  unexpected: %s", record.Describe())
+		}
+	}
+	for _, expectation := range strict.expectations {
+		if !expectation.matched {
+			fmt.Fprintf(&builder, "\n  not logged: %-5s /%s/", expectation.level, expectation.pattern)
+		}
+	}
+	strict.lock.Release()
+	if builder.Len() > 0 {
+		strict.t.Errorf("StubLogger recorded unexpected messages:%s", builder.String())
+	}
+}
+
+// Checks a record as it is logged, keeping it if it was not expected or allowed.
+func (this *stubStrict) check(record StubRecord) {
+	if record.Level <= LvNone || record.Level > LvWarn {
+		return
+	}
+	this.lock.Acquire()
+	defer this.lock.Release()
+	if !this.match(record) {
+		this.unexpected = append(this.unexpected, record)
+	}
+}
+
+// Reports whether record was expected or allowed, marking matched expectations.
+// Caller must hold lock.
+func (this *stubStrict) match(record StubRecord) bool {
+	text := record.String()
+	for i := range this.expectations {
+		expectation := &this.expectations[i]
+		if expectation.level == record.Level && expectation.pattern.MatchString(text) {
+			expectation.matched = true
+			return true
+		}
+	}
+	for _, pattern := range this.allowed {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
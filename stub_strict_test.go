@@ -0,0 +1,121 @@
+package logger
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestStubLoggerStrict(t *testing.T) {
+	t.Run("Passes when only info/debug are logged", func(t *testing.T) {
+		fake := fakeTB{}
+		stub := NewStubLogger()
+		stub.SetStrict(&fake)
+		stub.Info("info")
+		stub.Debug("debug")
+		fake.runCleanups()
+		if len(fake.errors) != 0 {
+			t.Errorf("Expected no failures. Received: %v", fake.errors)
+		}
+	})
+
+	t.Run("Fails on unexpected warn/error", func(t *testing.T) {
+		fake := fakeTB{}
+		stub := NewStubLogger()
+		stub.SetStrict(&fake)
+		stub.Warn("disk almost full")
+		stub.Error("disk full")
+		fake.runCleanups()
+		if len(fake.errors) != 1 {
+			t.Fatalf("Expected a failure. Received: %v", fake.errors)
+		}
+		rx := regexp.MustCompile(`unexpected: #1 WARN  stub_strict_test.go:[0-9]+: disk almost full\n  unexpected: #2 ERROR .*disk full$`)
+		if !rx.MatchString(fake.errors[0]) {
+			t.Errorf("Unexpected failure message. Received:\n%s", fake.errors[0])
+		}
+	})
+
+	t.Run("Expected and allowed messages pass", func(t *testing.T) {
+		fake := fakeTB{}
+		stub := NewStubLogger()
+		stub.SetStrict(&fake)
+		stub.Expect(LvError, regexp.MustCompile(`connection refused`))
+		stub.Allow(regexp.MustCompile(`^retrying`))
+		stub.Warn("retrying in 1s")
+		stub.Error("dial tcp: connection refused")
+		fake.runCleanups()
+		if len(fake.errors) != 0 {
+			t.Errorf("Expected no failures. Received: %v", fake.errors)
+		}
+	})
+
+	t.Run("Fails when expected message is not logged", func(t *testing.T) {
+		fake := fakeTB{}
+		stub := NewStubLogger()
+		stub.SetStrict(&fake)
+		stub.Expect(LvError, regexp.MustCompile(`connection refused`))
+		stub.Warn("connection refused")
+		fake.runCleanups()
+		if len(fake.errors) != 1 || !strings.Contains(fake.errors[0], "not logged: ERROR /connection refused/") {
+			t.Errorf("Expected unmatched expectation to be reported. Received: %v", fake.errors)
+		}
+	})
+
+	t.Run("Reports unexpected messages discarded by capacity", func(t *testing.T) {
+		fake := fakeTB{}
+		stub := NewStubLogger()
+		stub.SetStrict(&fake)
+		stub.SetCapacity(1)
+		stub.Error("disk full")
+		stub.Error("disk still full")
+		fake.runCleanups()
+		if len(fake.errors) != 1 || !strings.Contains(fake.errors[0], ": disk full\n") || !strings.Contains(fake.errors[0], ": disk still full") {
+			t.Errorf("Expected both errors to be reported. Received: %v", fake.errors)
+		}
+	})
+
+	t.Run("Reports unexpected messages discarded by Reset", func(t *testing.T) {
+		fake := fakeTB{}
+		stub := NewStubLogger()
+		stub.SetStrict(&fake)
+		stub.Error("unexpected")
+		stub.Reset()
+		fake.runCleanups()
+		if len(fake.errors) != 1 || !strings.Contains(fake.errors[0], ": unexpected") {
+			t.Errorf("Expected error to be reported. Received: %v", fake.errors)
+		}
+	})
+
+	t.Run("Keeps only unexpected messages", func(t *testing.T) {
+		fake := fakeTB{}
+		stub := NewStubLogger()
+		stub.SetStrict(&fake)
+		stub.SetCapacity(1)
+		stub.Allow(regexp.MustCompile(`^retrying`))
+		for i := 0; i < 100; i++ {
+			stub.Warn("retrying in 1s")
+		}
+		stub.Error("disk full")
+		if len(stub.strict.unexpected) != 1 {
+			t.Errorf("Expected only the unexpected message to be kept. Received: %v", stub.strict.unexpected)
+		}
+	})
+
+	t.Run("Expected after being logged", func(t *testing.T) {
+		fake := fakeTB{}
+		stub := NewStubLogger()
+		stub.SetStrict(&fake)
+		stub.Error("dial tcp: connection refused")
+		stub.Expect(LvError, regexp.MustCompile(`connection refused`))
+		fake.runCleanups()
+		if len(fake.errors) != 0 {
+			t.Errorf("Expected no failures. Received: %v", fake.errors)
+		}
+	})
+
+	t.Run("Expectations are ignored when not strict", func(t *testing.T) {
+		stub := NewStubLogger()
+		stub.Expect(LvError, regexp.MustCompile(`.*`))
+		stub.verifyStrict()
+	})
+}
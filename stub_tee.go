@@ -0,0 +1,34 @@
+package logger
+
+import "flag"
+
+// TeeMode determines when StubLogger writes records to it's output (see StubLogger.SetOutput).
+type TeeMode int8
+
+const (
+	TeeModeNever   TeeMode = iota // only record messages (default)
+	TeeModeAlways                 // record and write messages
+	TeeModeVerbose                // record messages, and write them when tests are run with -v
+)
+
+// Reports whether records should be written to the output in this mode.
+func (m TeeMode) enabled() bool {
+	switch m {
+	case TeeModeAlways:
+		return true
+	case TeeModeVerbose:
+		return testVerbose()
+	}
+	return false
+}
+
+// Reports whether the test binary was run with -v.
+func testVerbose() bool {
+	f := flag.Lookup("test.v")
+	if f == nil {
+		return false
+	}
+	// 'go test -json' sets -test.v=test2json
+	v := f.Value.String()
+	return v == "true" || v == "test2json"
+}
@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"testing"
+)
+
+func TestStubLoggerTee(t *testing.T) {
+	setVerbose := func(t *testing.T, verbose string) {
+		f := flag.Lookup("test.v")
+		prev := f.Value.String()
+		f.Value.Set(verbose)
+		t.Cleanup(func() { f.Value.Set(prev) })
+	}
+	tcases := []struct {
+		test    string
+		mode    TeeMode
+		verbose string
+		written bool
+	}{
+		{test: "Never", mode: TeeModeNever, verbose: "true", written: false},
+		{test: "Always", mode: TeeModeAlways, verbose: "false", written: true},
+		{test: "Verbose with -v", mode: TeeModeVerbose, verbose: "true", written: true},
+		{test: "Verbose without -v", mode: TeeModeVerbose, verbose: "false", written: false},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			setVerbose(t, tcase.verbose)
+			var buf bytes.Buffer
+			stub := NewStubLogger()
+			stub.SetFlags(log.Lmsgprefix)
+			stub.SetOutput(&buf)
+			stub.SetTee(tcase.mode)
+			stub.Warn("disk almost full", F("free", "2%"))
+
+			expect := ""
+			if tcase.written {
+				expect = "[WARN ] disk almost full free=2%\n"
+			}
+			if buf.String() != expect {
+				t.Errorf("Expected %q, Received %q", expect, buf.String())
+			}
+			if msgs := stub.Messages(LvWarn); len(msgs) != 1 {
+				t.Errorf("Expected message to be recorded. Received %v", msgs)
+			}
+		})
+	}
+
+	t.Run("Without output", func(t *testing.T) {
+		stub := NewStubLogger()
+		stub.SetTee(TeeModeAlways)
+		stub.Warn("disk almost full")
+		if msgs := stub.Messages(LvWarn); len(msgs) != 1 {
+			t.Errorf("Expected message to be recorded. Received %v", msgs)
+		}
+	})
+}
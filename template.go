@@ -0,0 +1,189 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidTemplate = errors.New("invalid log template")
+
+// Characters that are omitted after a placeholder that renders nothing (ex. {caller} without log.Lshortfile).
+const templateSeparators = " \t:|-,;"
+
+// Named layouts for the {time:layout} placeholder. Other layouts are used as a time.Format layout.
+var templateTimeLayouts = map[string]string{
+	"rfc3339":      time.RFC3339,
+	"rfc3339milli": "2006-01-02T15:04:05.000Z07:00",
+	"rfc3339nano":  time.RFC3339Nano,
+	"datetime":     "2006-01-02 15:04:05",
+	"kitchen":      time.Kitchen,
+}
+
+// TemplateFormatter renders lines from a template of {placeholders}, for matching existing log conventions.
+//
+//	{time}          timestamp in the Logger's TimeFormat, or requested by the log.Ldate/log.Ltime/log.Lmicroseconds flags
+//	{time:layout}   timestamp in a named layout (rfc3339, rfc3339milli, rfc3339nano, datetime, kitchen) or a time.Format layout
+//	{level}         level label (see Labels)
+//	{level:5}       level label, padded to a width
+//	{caller}        file:line, when flags include log.Lshortfile or log.Llongfile
+//	{msg}           message
+//	{fields}        fields as key=value
+//	{{ and }}       literal braces
+//
+// Separators following a placeholder that renders nothing are omitted, and stack traces are appended to the line.
+//
+//	Ex.
+//	    "{time:rfc3339} {level:5} {caller} {msg} {fields}"
+//	    2009-01-23T01:23:23Z WARN  main.go:23 disk almost full free=2%
+type TemplateFormatter struct {
+	Labels map[LogLevel]string // overrides the label of levels (ex. LvWarn: "WARNING")
+
+	parts []templatePart
+}
+
+// A literal, or a placeholder when name is set.
+type templatePart struct {
+	literal string
+	name    string
+	arg     string
+}
+
+// Creates a TemplateFormatter, returning an error wrapping ErrInvalidTemplate if the template cannot be parsed.
+func NewTemplateFormatter(template string) (*TemplateFormatter, error) {
+	parts, err := parseTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+	return &TemplateFormatter{Labels: map[LogLevel]string{}, parts: parts}, nil
+}
+
+func parseTemplate(template string) ([]templatePart, error) {
+	var parts []templatePart
+	literal := strings.Builder{}
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		switch {
+		case (c == '{' || c == '}') && i+1 < len(template) && template[i+1] == c:
+			literal.WriteByte(c)
+			i++
+		case c == '{':
+			end := strings.IndexByte(template[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unclosed '{' at offset %d", ErrInvalidTemplate, i)
+			}
+			part, err := parsePlaceholder(template[i+1 : i+end])
+			if err != nil {
+				return nil, err
+			}
+			if literal.Len() > 0 {
+				parts = append(parts, templatePart{literal: literal.String()})
+				literal.Reset()
+			}
+			parts = append(parts, part)
+			i += end
+		case c == '}':
+			return nil, fmt.Errorf("%w: unexpected '}' at offset %d", ErrInvalidTemplate, i)
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	if literal.Len() > 0 {
+		parts = append(parts, templatePart{literal: literal.String()})
+	}
+	return parts, nil
+}
+
+// Parses the contents of a placeholder (ex. "level:5").
+func parsePlaceholder(placeholder string) (templatePart, error) {
+	part := templatePart{name: placeholder}
+	if colon := strings.IndexByte(placeholder, ':'); colon >= 0 {
+		part.name, part.arg = placeholder[:colon], placeholder[colon+1:]
+	}
+	switch part.name {
+	case "time":
+		if layout, ok := templateTimeLayouts[part.arg]; ok {
+			part.arg = layout
+		}
+		return part, nil
+	case "level":
+		if part.arg == "" {
+			return part, nil
+		}
+		if width, err := strconv.Atoi(part.arg); err != nil || width < 0 {
+			return part, fmt.Errorf("%w: invalid width in {%s}", ErrInvalidTemplate, placeholder)
+		}
+		return part, nil
+	case "caller", "msg", "fields":
+		if part.arg != "" {
+			return part, fmt.Errorf("%w: {%s} does not accept arguments", ErrInvalidTemplate, part.name)
+		}
+		return part, nil
+	}
+	return part, fmt.Errorf("%w: unknown placeholder {%s}", ErrInvalidTemplate, placeholder)
+}
+
+func (f *TemplateFormatter) Format(r *Record, flags int) []byte {
+	var buf []byte
+	skipSeparators := false
+	for _, part := range f.parts {
+		if part.name == "" {
+			text := part.literal
+			if skipSeparators {
+				text = strings.TrimLeft(text, templateSeparators)
+			}
+			buf = append(buf, text...)
+			skipSeparators = false
+			continue
+		}
+		n := len(buf)
+		buf = f.appendPlaceholder(buf, part, r, flags)
+		skipSeparators = len(buf) == n
+	}
+	buf = bytes.TrimRight(buf, " \t")
+	buf = appendStack(buf, r.Stack)
+	return append(buf, '\n')
+}
+
+func (f *TemplateFormatter) appendPlaceholder(buf []byte, part templatePart, r *Record, flags int) []byte {
+	switch part.name {
+	case "time":
+		if part.arg == "" {
+			return appendTimestamp(buf, r, flags)
+		}
+		t := r.Time
+		if flags&log.LUTC != 0 {
+			t = t.UTC()
+		}
+		return t.AppendFormat(buf, part.arg)
+	case "level":
+		label, ok := f.Labels[r.Level]
+		if !ok {
+			label = r.Level.String()
+		}
+		buf = append(buf, label...)
+		if width, _ := strconv.Atoi(part.arg); width > len(label) {
+			buf = append(buf, strings.Repeat(" ", width-len(label))...)
+		}
+		return buf
+	case "caller":
+		return appendFuncCaller(buf, r, flags)
+	case "msg":
+		return append(buf, r.Message...)
+	case "fields":
+		for i, field := range textFields(r.Fields) {
+			if i > 0 {
+				buf = append(buf, ' ')
+			}
+			buf = append(buf, field.Key...)
+			buf = append(buf, '=')
+			buf = append(buf, field.Text...)
+		}
+		return buf
+	}
+	return buf
+}
@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"errors"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestTemplateFormatter(t *testing.T) {
+	r := Record{
+		Time:    time.Date(2009, 1, 23, 1, 23, 23, 123000000, time.UTC),
+		Level:   LvWarn,
+		Message: "disk almost full",
+		File:    "/src/main.go",
+		Line:    23,
+		Fields:  []Field{F("free", "2%"), F("mount", "/home")},
+	}
+	tcases := []struct {
+		test     string
+		template string
+		labels   map[LogLevel]string
+		flags    int
+		expect   string
+	}{
+		{
+			test:     "All placeholders",
+			template: "{time:rfc3339} {level:5} {caller} {msg} {fields}",
+			flags:    log.Lshortfile,
+			expect:   "2009-01-23T01:23:23Z WARN  main.go:23 disk almost full free=2% mount=/home\n",
+		},
+		{
+			test:     "Time from flags",
+			template: "{time} [{level}] {msg}",
+			flags:    log.LstdFlags,
+			expect:   "2009/01/23 01:23:23 [WARN] disk almost full\n",
+		},
+		{
+			test:     "Custom time layout",
+			template: "{time:2006-01-02 15:04:05.000} {msg}",
+			expect:   "2009-01-23 01:23:23.123 disk almost full\n",
+		},
+		{
+			test:     "Level labels",
+			template: "{level:7}| {msg}",
+			labels:   map[LogLevel]string{LvWarn: "WARNING"},
+			expect:   "WARNING| disk almost full\n",
+		},
+		{
+			test:     "Separators omitted after empty placeholders",
+			template: "{time} [{level}] {caller}: {msg} {fields}",
+			flags:    0,
+			expect:   "[WARN] disk almost full free=2% mount=/home\n",
+		},
+		{
+			test:     "Escaped braces",
+			template: "{{{level}}} {msg}",
+			expect:   "{WARN} disk almost full\n",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			f, err := NewTemplateFormatter(tcase.template)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if tcase.labels != nil {
+				f.Labels = tcase.labels
+			}
+			if received := string(f.Format(&r, tcase.flags)); received != tcase.expect {
+				t.Errorf("Expected %q, Received %q", tcase.expect, received)
+			}
+		})
+	}
+
+	t.Run("Trailing empty placeholder", func(t *testing.T) {
+		f, _ := NewTemplateFormatter("{level} {msg} {fields}")
+		r := Record{Level: LvInfo, Message: "hi"}
+		if received := string(f.Format(&r, 0)); received != "INFO hi\n" {
+			t.Errorf("Expected %q, Received %q", "INFO hi\n", received)
+		}
+	})
+}
+
+func TestNewTemplateFormatterErrors(t *testing.T) {
+	tcases := []struct {
+		test     string
+		template string
+	}{
+		{test: "Unknown placeholder", template: "{lvl} {msg}"},
+		{test: "Unclosed brace", template: "{level {msg}"},
+		{test: "Unexpected brace", template: "{level}} {msg}"},
+		{test: "Invalid width", template: "{level:x} {msg}"},
+		{test: "Unexpected argument", template: "{msg:5}"},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			if _, err := NewTemplateFormatter(tcase.template); !errors.Is(err, ErrInvalidTemplate) {
+				t.Errorf("Expected ErrInvalidTemplate, Received %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// TB is the subset of testing.TB used by loggers that are bound to a test.
+type TB interface {
+	Helper()
+	Log(args ...interface{})
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}
+
+// TestingLogger writes messages to a test's log (testing.T.Log),
+// so they are attached to the test that logged them, and only shown on failure or with -v.
+//
+// Messages logged after the test has completed are discarded.
+//
+// TestingLogger is threadsafe.
+type TestingLogger struct {
+	t     TB
+	level LogLevel
+	flags int
+	done  bool
+
+	lock *sync.Mutex
+}
+
+// Creates a TestingLogger that writes to t's log (ex. NewTestLogger(t) within a test).
+// Like StubLogger, it is created with the highest possible loglevel.
+func NewTestLogger(t TB) *TestingLogger {
+	l := &TestingLogger{
+		t:     t,
+		level: LvDebug,
+		flags: defaultLogFlags,
+		lock:  &sync.Mutex{},
+	}
+	t.Cleanup(func() {
+		l.lock.Lock()
+		defer l.lock.Unlock()
+		l.done = true
+	})
+	return l
+}
+
+func (this *TestingLogger) Flags() int {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.flags
+}
+
+func (this *TestingLogger) Level() LogLevel {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.level
+}
+
+func (this *TestingLogger) SetLevel(level LogLevel) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.level = level
+}
+
+func (this *TestingLogger) SetOutput(w io.Writer) {
+	// ignored, messages are always written to the test's log
+	return
+}
+
+// Flags are recorded, but not used. The test's log already includes the caller.
+func (this *TestingLogger) SetFlags(flags int) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.flags = flags
+}
+
+func (this *TestingLogger) Enabled(level LogLevel) bool {
+	return level > LvNone && this.Level() >= level
+}
+
+func (this *TestingLogger) Debug(v ...interface{}) {
+	this.t.Helper()
+	this.print(LvDebug, v)
+}
+
+func (this *TestingLogger) Info(v ...interface{}) {
+	this.t.Helper()
+	this.print(LvInfo, v)
+}
+
+func (this *TestingLogger) Warn(v ...interface{}) {
+	this.t.Helper()
+	this.print(LvWarn, v)
+}
+
+func (this *TestingLogger) Error(v ...interface{}) {
+	this.t.Helper()
+	this.print(LvError, v)
+}
+
+func (this *TestingLogger) Debugf(format string, v ...interface{}) {
+	this.t.Helper()
+	this.printf(LvDebug, format, v)
+}
+
+func (this *TestingLogger) Infof(format string, v ...interface{}) {
+	this.t.Helper()
+	this.printf(LvInfo, format, v)
+}
+
+func (this *TestingLogger) Warnf(format string, v ...interface{}) {
+	this.t.Helper()
+	this.printf(LvWarn, format, v)
+}
+
+func (this *TestingLogger) Errorf(format string, v ...interface{}) {
+	this.t.Helper()
+	this.printf(LvError, format, v)
+}
+
+func (this *TestingLogger) Log(level LogLevel, v ...interface{}) {
+	this.t.Helper()
+	this.print(level, v)
+}
+
+func (this *TestingLogger) Logf(level LogLevel, format string, v ...interface{}) {
+	this.t.Helper()
+	this.printf(level, format, v)
+}
+
+func (this *TestingLogger) LogFunc(level LogLevel, fn func() string) {
+	this.t.Helper()
+	if this.Enabled(level) {
+		this.output(&Record{Level: level, Message: fn()})
+	}
+}
+
+func (this *TestingLogger) print(level LogLevel, v []interface{}) {
+	this.t.Helper()
+	if this.Enabled(level) {
+		args, fields := splitFields(resolveLazy(v))
+		this.output(&Record{Level: level, Message: fmt.Sprint(args...), Fields: fields})
+	}
+}
+
+func (this *TestingLogger) printf(level LogLevel, format string, v []interface{}) {
+	this.t.Helper()
+	if this.Enabled(level) {
+		args, fields := splitFields(resolveLazy(v))
+		this.output(&Record{Level: level, Message: fmt.Sprintf(format, args...), Fields: fields})
+	}
+}
+
+func (this *TestingLogger) output(r *Record) {
+	this.t.Helper()
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.done {
+		return
+	}
+	this.t.Log(r.Level.prefix() + r.String())
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// fakeTB records calls, instead of writing to the test's log
+type fakeTB struct {
+	logs     []string
+	errors   []string
+	helpers  int
+	cleanups []func()
+}
+
+func (t *fakeTB) Helper() {
+	t.helpers++
+}
+
+func (t *fakeTB) Log(args ...interface{}) {
+	t.logs = append(t.logs, fmt.Sprint(args...))
+}
+
+func (t *fakeTB) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeTB) Cleanup(fn func()) {
+	t.cleanups = append(t.cleanups, fn)
+}
+
+func (t *fakeTB) runCleanups() {
+	for i := len(t.cleanups) - 1; i >= 0; i-- {
+		t.cleanups[i]()
+	}
+}
+
+func TestTestingLoggerImplementsInterface(t *testing.T) {
+	belongsToInterface := func(Interface) bool {
+		return true
+	}
+	if !belongsToInterface(NewTestLogger(t)) {
+		t.Error("TestingLogger does not conform to Interface")
+	}
+}
+
+func TestTestingLogger(t *testing.T) {
+	t.Run("Writes to test log", func(t *testing.T) {
+		fake := fakeTB{}
+		logger_ := NewTestLogger(&fake)
+		logger_.SetLevel(LvInfo)
+		logger_.Error("error", F("key", "val"))
+		logger_.Infof("info: %s", "foo")
+		logger_.Debug("debug")
+
+		expects := []string{"[ERROR] error key=val", "[INFO ] info: foo"}
+		if !reflect.DeepEqual(fake.logs, expects) {
+			t.Errorf("Expected '%v', Received '%v'", expects, fake.logs)
+		}
+		if fake.helpers == 0 {
+			t.Error("Expected log methods to be marked as helpers")
+		}
+	})
+
+	t.Run("Discards messages after test completes", func(t *testing.T) {
+		fake := fakeTB{}
+		logger_ := NewTestLogger(&fake)
+		fake.runCleanups()
+		logger_.Error("error")
+		if len(fake.logs) != 0 {
+			t.Errorf("Expected no messages after test completed. Received '%v'", fake.logs)
+		}
+	})
+
+	t.Run("Writes to testing.T", func(t *testing.T) {
+		logger_ := NewTestLogger(t)
+		logger_.Info("written to test log")
+	})
+}
@@ -0,0 +1,65 @@
+package logger
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/willjp/go-logger/internal/spinlock"
+)
+
+// TimeFormat determines how the timestamp of a line is rendered, by all of the Logger's builtin formatters.
+// Any time.Format layout can be used (ex. TimeFormat("2006-01-02 15:04:05.000")).
+// Timestamps are in UTC when flags include log.LUTC.
+type TimeFormat string
+
+const (
+	TimeFlags       TimeFormat = ""               // as requested by log.Ldate/log.Ltime/log.Lmicroseconds (default)
+	TimeRFC3339     TimeFormat = time.RFC3339     // ex. 2009-01-23T01:23:23Z
+	TimeRFC3339Nano TimeFormat = time.RFC3339Nano // ex. 2009-01-23T01:23:23.123456789Z
+	TimeUnixMilli   TimeFormat = "unixmilli"      // milliseconds since the unix epoch, ex. 1232673803123
+	TimeElapsed     TimeFormat = "elapsed"        // monotonic time since the first line, ex. 1.5023s
+	TimeDelta       TimeFormat = "delta"          // monotonic time since the previous line, ex. +12.5ms
+)
+
+// lineTimes tracks the times lines were logged at, for TimeElapsed and TimeDelta.
+type lineTimes struct {
+	start time.Time
+	last  time.Time
+	lock  spinlock.SpinLock
+}
+
+// Returns the duration since the first/previous line (depending on format), and records t as the latest line.
+func (lt *lineTimes) since(t time.Time, format TimeFormat) time.Duration {
+	lt.lock.Acquire()
+	defer lt.lock.Release()
+	if lt.start.IsZero() {
+		lt.start, lt.last = t, t
+	}
+	from := lt.start
+	if format == TimeDelta {
+		from = lt.last
+	}
+	lt.last = t
+	return t.Sub(from)
+}
+
+// Appends the timestamp of r in it's TimeFormat, or as requested by flags (without trailing space).
+func appendTimestamp(buf []byte, r *Record, flags int) []byte {
+	t := r.Time
+	if flags&log.LUTC != 0 {
+		t = t.UTC()
+	}
+	switch r.timeFormat {
+	case TimeFlags:
+		return appendTime(buf, t, flags)
+	case TimeUnixMilli:
+		return strconv.AppendInt(buf, t.UnixMilli(), 10)
+	case TimeElapsed:
+		return append(buf, r.elapsed.String()...)
+	case TimeDelta:
+		buf = append(buf, '+')
+		return append(buf, r.elapsed.String()...)
+	}
+	return t.AppendFormat(buf, string(r.timeFormat))
+}
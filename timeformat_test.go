@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stepClock advances by step each time it is read
+type stepClock struct {
+	now  time.Time
+	step time.Duration
+}
+
+func (c *stepClock) Now() time.Time {
+	now := c.now
+	c.now = c.now.Add(c.step)
+	return now
+}
+
+func TestAppendTimestamp(t *testing.T) {
+	at := time.Date(2009, 1, 23, 1, 23, 23, 123456789, time.FixedZone("EET", 2*60*60))
+	tcases := []struct {
+		test    string
+		format  TimeFormat
+		flags   int
+		elapsed time.Duration
+		expect  string
+	}{
+		{test: "Flags", format: TimeFlags, flags: log.LstdFlags, expect: "2009/01/23 01:23:23"},
+		{test: "RFC3339", format: TimeRFC3339, expect: "2009-01-23T01:23:23+02:00"},
+		{test: "RFC3339Nano UTC", format: TimeRFC3339Nano, flags: log.LUTC, expect: "2009-01-22T23:23:23.123456789Z"},
+		{test: "Unix milli", format: TimeUnixMilli, expect: "1232666603123"},
+		{test: "Custom layout", format: TimeFormat("15:04:05.000"), expect: "01:23:23.123"},
+		{test: "Elapsed", format: TimeElapsed, elapsed: 1500 * time.Millisecond, expect: "1.5s"},
+		{test: "Delta", format: TimeDelta, elapsed: 12 * time.Millisecond, expect: "+12ms"},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			r := Record{Time: at, timeFormat: tcase.format, elapsed: tcase.elapsed}
+			if received := string(appendTimestamp(nil, &r, tcase.flags)); received != tcase.expect {
+				t.Errorf("Expected %q, Received %q", tcase.expect, received)
+			}
+		})
+	}
+}
+
+func TestLoggerSetTimeFormat(t *testing.T) {
+	start := time.Date(2009, 1, 23, 1, 23, 23, 0, time.UTC)
+	tcases := []struct {
+		test      string
+		format    TimeFormat
+		formatter Formatter
+		expect    string
+	}{
+		{
+			test:   "Elapsed",
+			format: TimeElapsed,
+			expect: "[WARN ] 0s first\n[WARN ] 250ms second\n[WARN ] 500ms third\n",
+		},
+		{
+			test:   "Delta",
+			format: TimeDelta,
+			expect: "[WARN ] +0s first\n[WARN ] +250ms second\n[WARN ] +250ms third\n",
+		},
+		{
+			test:      "Console",
+			format:    TimeRFC3339,
+			formatter: &ConsoleFormatter{},
+			expect:    "2009-01-23T01:23:23Z WARN  first\n2009-01-23T01:23:23Z WARN  second\n2009-01-23T01:23:23Z WARN  third\n",
+		},
+		{
+			test:      "JSON unix milli",
+			format:    TimeUnixMilli,
+			formatter: &JSONFormatter{},
+			expect:    `{"time":1232673803000,"level":"WARN","msg":"first"}` + "\n" + `{"time":1232673803250,"level":"WARN","msg":"second"}` + "\n" + `{"time":1232673803500,"level":"WARN","msg":"third"}` + "\n",
+		},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.test, func(t *testing.T) {
+			var buf strings.Builder
+			lg := New(&buf, WithTimeFormat(tcase.format), WithClock(&stepClock{now: start, step: 250 * time.Millisecond}))
+			lg.SetFlags(0)
+			if tcase.formatter != nil {
+				lg.SetFormatter(tcase.formatter)
+			}
+			lg.Warn("first")
+			lg.Warn("second")
+			lg.Warn("third")
+			if buf.String() != tcase.expect {
+				t.Errorf("Expected %q, Received %q", tcase.expect, buf.String())
+			}
+		})
+	}
+}